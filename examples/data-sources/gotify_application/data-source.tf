@@ -0,0 +1,14 @@
+# Look up an application by its exact name...
+data "gotify_application" "by_name" {
+  name = "Diun"
+}
+
+# ...or by its numeric id
+data "gotify_application" "by_id" {
+  id = 1
+}
+
+output "diun_token" {
+  sensitive = true
+  value     = data.gotify_application.by_name.token
+}
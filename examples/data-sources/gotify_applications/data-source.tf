@@ -0,0 +1,8 @@
+data "gotify_applications" "monitoring" {
+  name_regex = "^monitoring-"
+  internal   = false
+}
+
+output "monitoring_apps" {
+  value = { for app in data.gotify_applications.monitoring.applications : app.name => app.id }
+}
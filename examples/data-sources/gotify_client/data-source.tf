@@ -0,0 +1,9 @@
+# Look up a client by its exact name...
+data "gotify_client" "by_name" {
+  name = "Home Dashboard"
+}
+
+# ...or by its numeric id
+data "gotify_client" "by_id" {
+  id = 1
+}
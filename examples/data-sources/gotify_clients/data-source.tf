@@ -0,0 +1,5 @@
+data "gotify_clients" "all" {}
+
+output "client_names" {
+  value = data.gotify_clients.all.clients[*].name
+}
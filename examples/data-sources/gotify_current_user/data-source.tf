@@ -0,0 +1,8 @@
+data "gotify_current_user" "this" {
+  lifecycle {
+    postcondition {
+      condition     = self.admin
+      error_message = "The provider must be configured with an admin to manage users, ${self.name} is not one."
+    }
+  }
+}
@@ -0,0 +1,11 @@
+data "gotify_plugins" "enabled" {
+  enabled = true
+}
+
+# Manage every enabled plugin, for example to keep them enabled
+resource "gotify_plugin" "enabled" {
+  for_each = { for plugin in data.gotify_plugins.enabled.plugins : plugin.module_path => plugin }
+
+  module_path = each.key
+  enabled     = true
+}
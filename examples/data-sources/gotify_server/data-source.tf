@@ -0,0 +1,14 @@
+data "gotify_server" "this" {}
+
+# Application default priorities require Gotify 2.5.0 or newer.
+resource "gotify_application" "example" {
+  name             = "Diun"
+  default_priority = 5
+
+  lifecycle {
+    precondition {
+      condition     = data.gotify_server.this.version_major > 2 || (data.gotify_server.this.version_major == 2 && data.gotify_server.this.version_minor >= 5)
+      error_message = "Gotify ${data.gotify_server.this.version} is too old, default priorities require 2.5.0 or newer."
+    }
+  }
+}
@@ -0,0 +1,11 @@
+# The client only exists while Terraform runs, its token never ends up in the state.
+ephemeral "gotify_client" "reader" {
+  name = "Terraform"
+}
+
+# Ephemeral values can be used in provider configurations, for example to
+# authenticate a second provider instance as that client.
+provider "gotify" {
+  alias        = "reader"
+  client_token = ephemeral.gotify_client.reader.token
+}
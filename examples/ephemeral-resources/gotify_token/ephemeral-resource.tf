@@ -0,0 +1,15 @@
+# Keep the token out of the state...
+resource "gotify_application" "backups" {
+  name        = "Backups"
+  store_token = false
+}
+
+# ...and only read it where it is needed, for example in a provider configuration.
+ephemeral "gotify_token" "backups" {
+  application_id = gotify_application.backups.id
+}
+
+# Plugin tokens are read by the module path.
+ephemeral "gotify_token" "slack" {
+  module_path = "github.com/LukasKnuth/gotify-slack-webhook"
+}
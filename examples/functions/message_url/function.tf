@@ -0,0 +1,5 @@
+# https://gotify.example.com/message?token=...
+output "publish_url" {
+  value     = provider::gotify::message_url("https://gotify.example.com", gotify_application.example.token)
+  sensitive = true
+}
@@ -0,0 +1,5 @@
+# wss://gotify.example.com/stream?token=...
+output "stream_url" {
+  value     = provider::gotify::stream_url("https://gotify.example.com", gotify_client.example.token)
+  sensitive = true
+}
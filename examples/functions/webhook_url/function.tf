@@ -0,0 +1,6 @@
+# The plugin id is listed by the gotify_plugins data source
+# https://gotify.example.com/plugin/1/custom/.../slack_webhook
+output "slack_webhook_url" {
+  value     = provider::gotify::webhook_url("https://gotify.example.com", 1, gotify_plugin.example.token, "slack_webhook")
+  sensitive = true
+}
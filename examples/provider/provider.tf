@@ -5,9 +5,41 @@ provider "gotify" {
   password = "admin"                  # or GOTIFY_PASSWORD
 }
 
+# Authenticate with a client token instead of username/password
+provider "gotify" {
+  endpoint     = "http://my.gotify.local"
+  client_token = var.gotify_client_token # or GOTIFY_CLIENT_TOKEN
+}
+
 # When Gotify is behind a reverse proxy and DNS isn't setup yet
 provider "gotify" {
   endpoint    = "http://192.168.1.4" # public, static IP of deployment
   host_header = "my.gotify.local"    # Host header expected by reverse proxy
 }
 
+
+# When Gotify uses a certificate from an internal CA and requires mutual TLS
+provider "gotify" {
+  endpoint           = "https://gotify.internal"
+  ca_certificate     = "${path.module}/internal-ca.pem" # or GOTIFY_CA_CERTIFICATE, PEM content works too
+  client_certificate = "${path.module}/terraform.crt"   # or GOTIFY_CLIENT_CERTIFICATE
+  client_key         = "${path.module}/terraform.key"   # or GOTIFY_CLIENT_KEY
+}
+
+# When Gotify might still be starting up, e.g. during a cluster bootstrap
+provider "gotify" {
+  endpoint        = "http://my.gotify.local"
+  max_retries     = 10  # Defaults to 3
+  retry_max_wait  = 60  # Seconds between retries at most, defaults to 30
+  request_timeout = 600 # Seconds per request including its retries, defaults to 30
+}
+
+# When Gotify is deployed in the same apply, wait for it before configuring it
+provider "gotify" {
+  endpoint = "http://my.gotify.local"
+
+  wait_for_ready {
+    timeout       = 600 # Seconds, defaults to 300
+    poll_interval = 10  # Seconds, defaults to 5
+  }
+}
@@ -0,0 +1,5 @@
+# Import by numeric id...
+terraform import gotify_application.example 1
+
+# ...or by the exact application name
+terraform import gotify_application.example "Diun"
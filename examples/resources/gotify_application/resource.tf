@@ -1,4 +1,25 @@
 resource "gotify_application" "example" {
-  name        = "Diun"
-  description = "Notifies about outdated Container images"
+  name             = "Diun"
+  description      = "Notifies about outdated Container images"
+  default_priority = 5
+}
+
+# Custom images are uploaded from a local file or inline base64 content.
+# Changes to the file content are detected during plan.
+resource "gotify_application" "with_image" {
+  name = "Backups"
+
+  image {
+    source = "${path.module}/backups.png"
+  }
+}
+
+# Change any of the triggers to rotate the token. Gotify can't rotate
+# tokens in place, so the application is replaced.
+resource "gotify_application" "rotating" {
+  name = "CI"
+
+  rotation_triggers = {
+    leaked = "2024-06-01"
+  }
 }
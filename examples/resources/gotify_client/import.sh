@@ -0,0 +1,5 @@
+# Import by numeric id...
+terraform import gotify_client.example 1
+
+# ...or by the exact client name
+terraform import gotify_client.example "Home Dashboard"
@@ -1,3 +1,30 @@
 resource "gotify_client" "example" {
   name = "Home Dashboard"
 }
+
+# Change any of the triggers to rotate the token. Gotify can't rotate
+# tokens in place, so the client is replaced.
+resource "gotify_client" "rotating" {
+  name = "Phone"
+
+  rotation_triggers = {
+    lost_phone = "2024-06-01"
+  }
+}
+
+# Keep the token out of the state, read it with the ephemeral gotify_token
+# resource where it is needed instead.
+resource "gotify_client" "secretive" {
+  name        = "Laptop"
+  store_token = false
+}
+
+# Give up sooner when the server hangs, the defaults are 20 minutes.
+resource "gotify_client" "impatient" {
+  name = "Kiosk"
+
+  timeouts {
+    create = "2m"
+    delete = "2m"
+  }
+}
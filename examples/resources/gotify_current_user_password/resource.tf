@@ -0,0 +1,17 @@
+variable "admin_password" {
+  type      = string
+  sensitive = true
+}
+
+# Locks down the default "admin" user right after the server is set up.
+# Later runs configure the provider with the new password instead.
+resource "gotify_current_user_password" "admin" {
+  pass_wo = var.admin_password
+}
+
+# Created with the new password.
+resource "gotify_application" "example" {
+  name = "Diun"
+
+  depends_on = [gotify_current_user_password.admin]
+}
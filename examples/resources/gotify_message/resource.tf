@@ -0,0 +1,15 @@
+resource "gotify_application" "deployments" {
+  name = "Deployments"
+}
+
+resource "gotify_message" "example" {
+  application_id = gotify_application.deployments.id
+  title          = "Production"
+  message        = "Database **migrated** to version 42"
+  priority       = 5
+
+  extras = jsonencode({
+    "client::display"      = { contentType = "text/markdown" }
+    "client::notification" = { click = { url = "https://status.example.com" } }
+  })
+}
@@ -0,0 +1 @@
+terraform import gotify_plugin.example github.com/LukasKnuth/gotify-slack-webhook
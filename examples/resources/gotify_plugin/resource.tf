@@ -3,6 +3,22 @@ resource "gotify_plugin" "example" {
   enabled     = true
 }
 
+# Plugins with the "configurer" capability accept a YAML string...
+resource "gotify_plugin" "configured_yaml" {
+  module_path = "github.com/gotify/plugin-template"
+  enabled     = true
+  config      = file("${path.module}/plugin-config.yml")
+}
+
+# ...or an object, which is encoded to YAML.
+resource "gotify_plugin" "configured_object" {
+  module_path = "github.com/gotify/plugin-template"
+  enabled     = true
+  config = {
+    magic_string = "hello"
+  }
+}
+
 # NOTE: As stated in the field description, you need to set host/port and plugin prefix yourself.
 output "webhhok_path" {
   sensitive = true
@@ -0,0 +1,18 @@
+variable "jane_password" {
+  type      = string
+  sensitive = true
+}
+
+resource "gotify_user" "example" {
+  name  = "jane"
+  pass  = var.jane_password
+  admin = false
+}
+
+# With Terraform 1.11 or newer, the password can be write-only, so it never
+# ends up in the state. Bump the version to send a new password.
+resource "gotify_user" "write_only" {
+  name            = "john"
+  pass_wo         = var.jane_password
+  pass_wo_version = 1
+}
@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-gotify/provider/internal"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	// Ensure implementation satisfies expected interfaces - compilition fails here otherwise.
+	_ datasource.DataSource                     = &ApplicationDataSource{}
+	_ datasource.DataSourceWithConfigure        = &ApplicationDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &ApplicationDataSource{}
+)
+
+type ApplicationDataSource struct {
+	gotify *internal.AuthedGotifyClient
+}
+
+func NewApplicationDataSource() datasource.DataSource {
+	return &ApplicationDataSource{}
+}
+
+type ApplicationDataSourceModel struct {
+	// Lookup by either of these
+	Id         types.Int64  `tfsdk:"id"`
+	Name       types.String `tfsdk:"name"`
+	StoreToken types.Bool   `tfsdk:"store_token"`
+	// Read-only
+	Description types.String `tfsdk:"description"`
+	Token       types.String `tfsdk:"token"`
+}
+
+func (d *ApplicationDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_application"
+}
+
+func (d *ApplicationDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "Looks up an existing application, for example one managed by another Terraform configuration.",
+		MarkdownDescription: "Looks up an existing application by its `id` or its exact `name`. Exactly one application must match.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Numeric identifier of the application to look up.",
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Exact name of the application to look up.",
+			},
+			"store_token": storeTokenDataSourceAttribute(),
+			"description": schema.StringAttribute{
+				Computed:    true,
+				Description: "Description of the application.",
+			},
+			"token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The Token to both identify the sending application AND authenticate it against the server.",
+			},
+		},
+	}
+}
+
+func (d *ApplicationDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.AtLeastOneOf(path.MatchRoot("id"), path.MatchRoot("name")),
+	}
+}
+
+func (d *ApplicationDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		// IMPORTANT: This method is called MULTIPLE times. An initial call might not have configured the Provider yet, so we need
+		// to handle this gracefully. It will eventually be called with a configured provider.
+		return
+	}
+
+	client, ok := req.ProviderData.(*internal.AuthedGotifyClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *AuthedGotifyClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.gotify = client
+}
+
+func (d *ApplicationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ApplicationDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	app_list, err := d.gotify.GetApps(ctx)
+	if err != nil {
+		resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Empty(), "Gotify API Request failed", err))
+		return
+	}
+
+	found := findApplications(app_list, data.Id, data.Name)
+	if len(found) == 0 {
+		resp.Diagnostics.AddError(
+			"No matching application found",
+			fmt.Sprintf("There is no application with %s. Check that it exists and is owned by the configured user.", lookupCriteria(data.Id, data.Name)),
+		)
+		return
+	} else if len(found) > 1 {
+		resp.Diagnostics.AddError(
+			"Multiple matching applications found",
+			fmt.Sprintf("There are %d applications with %s. Look up the application by its id instead.", len(found), lookupCriteria(data.Id, data.Name)),
+		)
+		return
+	}
+
+	data.Id = types.Int64Value(int64(found[0].ID))
+	data.Name = types.StringValue(found[0].Name)
+	data.Description = types.StringValue(found[0].Description)
+	data.Token = storedToken(data.StoreToken, found[0].Token)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Describes what a lookup by id and/or name was looking for, for use in diagnostics.
+func lookupCriteria(id types.Int64, name types.String) string {
+	if !id.IsNull() && !name.IsNull() {
+		return fmt.Sprintf("id %d and name %q", id.ValueInt64(), name.ValueString())
+	} else if !id.IsNull() {
+		return fmt.Sprintf("id %d", id.ValueInt64())
+	}
+	return fmt.Sprintf("name %q", name.ValueString())
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestApplicationDataSource(t *testing.T) {
+	testAcc(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Lookup by id and by name
+			{
+				Config: providerConfig + `
+resource "gotify_application" "test" {
+ name = "Lookup"
+ description = "Looked up by data source"
+}
+
+data "gotify_application" "by_id" {
+ id = gotify_application.test.id
+}
+
+data "gotify_application" "by_name" {
+ name = gotify_application.test.name
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.gotify_application.by_id", "name", "gotify_application.test", "name"),
+					resource.TestCheckResourceAttrPair("data.gotify_application.by_id", "token", "gotify_application.test", "token"),
+					resource.TestCheckResourceAttr("data.gotify_application.by_id", "description", "Looked up by data source"),
+					resource.TestCheckResourceAttrPair("data.gotify_application.by_name", "id", "gotify_application.test", "id"),
+					resource.TestCheckResourceAttrPair("data.gotify_application.by_name", "token", "gotify_application.test", "token"),
+				),
+			},
+			// Keep the token out of the state, like the resource
+			{
+				Config: providerConfig + `
+resource "gotify_application" "test" {
+ name = "Lookup"
+ description = "Looked up by data source"
+ store_token = false
+}
+
+data "gotify_application" "by_id" {
+ id = gotify_application.test.id
+ store_token = false
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.gotify_application.by_id", "name", "gotify_application.test", "name"),
+					resource.TestCheckNoResourceAttr("data.gotify_application.by_id", "token"),
+				),
+			},
+			// No match
+			{
+				Config: providerConfig + `
+data "gotify_application" "missing" {
+ name = "Does not exist"
+}
+`,
+				ExpectError: regexp.MustCompile("No matching application found"),
+			},
+		},
+	})
+}
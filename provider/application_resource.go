@@ -1,23 +1,42 @@
 package provider
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
 	"terraform-provider-gotify/provider/internal"
 
+	"github.com/go-openapi/runtime"
 	"github.com/gotify/go-api-client/v2/client/application"
 	"github.com/gotify/go-api-client/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var (
 	// Ensure implementation satisfies expected interfaces - compilition fails here otherwise.
-	_ resource.Resource              = &ApplicationResource{}
-	_ resource.ResourceWithConfigure = &ApplicationResource{}
+	_ resource.Resource                = &ApplicationResource{}
+	_ resource.ResourceWithConfigure   = &ApplicationResource{}
+	_ resource.ResourceWithImportState = &ApplicationResource{}
+	_ resource.ResourceWithModifyPlan  = &ApplicationResource{}
 )
 
 type ApplicationResource struct {
@@ -29,11 +48,24 @@ func NewApplicationResource() resource.Resource {
 }
 
 type ApplicationResourceModel struct {
-	Name        types.String `tfsdk:"name"`
-	Description types.String `tfsdk:"description"`
+	Name             types.String           `tfsdk:"name"`
+	Description      types.String           `tfsdk:"description"`
+	DefaultPriority  types.Int64            `tfsdk:"default_priority"`
+	Image            *ApplicationImageModel `tfsdk:"image"`
+	RotationTriggers types.Map              `tfsdk:"rotation_triggers"`
+	StoreToken       types.Bool             `tfsdk:"store_token"`
+	Timeouts         timeouts.Value         `tfsdk:"timeouts"`
 	// Read-only after apply
-	Id    types.Int64  `tfsdk:"id"`
-	Token types.String `tfsdk:"token"`
+	Id       types.Int64  `tfsdk:"id"`
+	Token    types.String `tfsdk:"token"`
+	ImageUrl types.String `tfsdk:"image_url"`
+}
+
+type ApplicationImageModel struct {
+	Source        types.String `tfsdk:"source"`
+	ContentBase64 types.String `tfsdk:"content_base64"`
+	// Read-only, computed during plan
+	Sha256 types.String `tfsdk:"sha256"`
 }
 
 func (r *ApplicationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -43,7 +75,7 @@ func (r *ApplicationResource) Metadata(ctx context.Context, req resource.Metadat
 func (r *ApplicationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description:         "An application is used to publish messages to Gotify from a specific App. Each app receives it's own channel where all its messages end up in.",
-		MarkdownDescription: "After applying the resource, use the `token` to send messages from your application.",
+		MarkdownDescription: "After applying the resource, use the `token` to send messages from your application.\n\nExisting applications can be imported by their numeric `id` or by their exact `name`.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.Int64Attribute{
 				Computed: true,
@@ -58,13 +90,54 @@ func (r *ApplicationResource) Schema(ctx context.Context, req resource.SchemaReq
 			},
 			"description": schema.StringAttribute{
 				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
 				Description: "Description of the application sending messages. Will show up in the Apps list.",
 			},
+			"default_priority": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(0),
+				Description:         "Priority of messages sent by this application that don't set their own priority. Requires Gotify 2.5.0 or newer.",
+				MarkdownDescription: "Priority of messages sent by this application that don't set their own priority. Must be between `0` and `10`.\n\nRequires Gotify 2.5.0 or newer, older servers report an error if this is set to anything but `0`.",
+				Validators: []validator.Int64{
+					int64validator.Between(0, 10),
+				},
+			},
 			"token": schema.StringAttribute{
 				Computed:    true,
 				Sensitive:   true,
 				Description: "The Token to both identify the sending application AND authenticate it against the server.",
 			},
+			"rotation_triggers": rotationTriggersAttribute("application"),
+			"store_token":       storeTokenAttribute("application"),
+			"image_url": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "Path of the application image on the server, relative to the endpoint. Points to the default image if no custom image is set.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Update: true, Delete: true}),
+			"image": schema.SingleNestedBlock{
+				Description: "Custom image shown for the application in the UI. Removing the block reverts to the default image.",
+				Attributes: map[string]schema.Attribute{
+					"source": schema.StringAttribute{
+						Optional:    true,
+						Description: "Path to a local image file to upload. Conflicts with `content_base64`.",
+					},
+					"content_base64": schema.StringAttribute{
+						Optional:    true,
+						Description: "Base64 encoded image content to upload, for example from `filebase64()`. Conflicts with `source`.",
+					},
+					"sha256": schema.StringAttribute{
+						Computed:    true,
+						Description: "SHA256 hash of the image content. Changes whenever the image content changes.",
+					},
+				},
+			},
 		},
 	}
 }
@@ -99,23 +172,39 @@ func (r *ApplicationResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
-	// Send the request
-	params := application.NewCreateAppParams()
-	params.Body = &models.Application{
-		Name:        data.Name.ValueString(),
-		Description: data.Description.ValueString(),
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	app, err := r.gotify.Client.Application.CreateApp(params, r.gotify.Auth)
+	tflog.Debug(ctx, "Creating application", map[string]interface{}{"name": data.Name.ValueString()})
+
+	// Send the request
+	app, err := r.gotify.CreateApp(ctx, toApplication(&data))
 	if err != nil {
-		resp.Diagnostics.AddError("Gotify API Request failed", err.Error())
+		resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Root("name"), "Gotify API Request failed", err))
 		return
 	}
 
+	// Upload the custom image, if any
+	if data.Image != nil {
+		uploaded, err := r.uploadImage(ctx, int64(app.ID), data.Image)
+		if err != nil {
+			resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Root("image"), "Could not upload application image", err))
+			// The app exists already, keep it in state so it's not orphaned
+			data.Image = nil
+		} else {
+			app.Image = uploaded.Image
+		}
+	}
+
 	// Update model with computed information
-	data.Id = types.Int64Value(int64(app.Payload.ID))
-	data.Token = types.StringValue(app.Payload.Token)
-	data.Name = types.StringValue(app.Payload.Name)
-	data.Description = types.StringValue(app.Payload.Description)
+	data.Id = types.Int64Value(int64(app.ID))
+	data.Token = storedToken(data.StoreToken, app.Token)
+	data.Name = types.StringValue(app.Name)
+	data.Description = types.StringValue(app.Description)
+	data.ImageUrl = types.StringValue(app.Image)
+	resp.Diagnostics.Append(applyDefaultPriority(app, &data)...)
 
 	// Write new data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -130,27 +219,37 @@ func (r *ApplicationResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Reading application", map[string]interface{}{"id": state.Id.ValueInt64()})
+
 	// Read all apps
-	params := application.NewGetAppsParams()
-	app_list, err := r.gotify.Client.Application.GetApps(params, r.gotify.Auth)
+	app_list, err := r.gotify.GetApps(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Gotify API Request failed", err.Error())
+		resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Root("id"), "Gotify API Request failed", err))
 		return
 	}
 
 	// Find this application and it's data
-	var found *models.Application
-	for _, app := range app_list.Payload {
-		if app.ID == uint(state.Id.ValueInt64()) {
-			found = app
-			break
-		}
-	}
-	if found != nil {
+	if matches := findApplications(app_list, state.Id, types.StringNull()); len(matches) > 0 {
+		found := matches[0]
 		// Update information on state
 		state.Name = types.StringValue(found.Name)
 		state.Description = types.StringValue(found.Description)
-		state.Token = types.StringValue(found.Token)
+		state.StoreToken = types.BoolValue(storeToken(state.StoreToken))
+		state.Token = storedToken(state.StoreToken, found.Token)
+		state.ImageUrl = types.StringValue(found.Image)
+		if state.Image != nil && found.Image == internal.DefaultApplicationImage {
+			// The custom image was removed from the server, drop it to plan a new upload.
+			state.Image = nil
+		}
+		if found.DefaultPriority != nil {
+			// Older servers don't report it, keep what we know in that case
+			state.DefaultPriority = types.Int64Value(*found.DefaultPriority)
+		}
 
 		// Write new information to tf-state
 		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
@@ -162,36 +261,167 @@ func (r *ApplicationResource) Read(ctx context.Context, req resource.ReadRequest
 }
 
 func (r *ApplicationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data ApplicationResourceModel
+	var data, state ApplicationResourceModel
 
 	// Read planned changes from the Terraform Plan
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Create API request
-	params := application.NewUpdateApplicationParams()
-	params.ID = data.Id.ValueInt64()
-	params.Body = &models.Application{
-		Name:        data.Name.ValueString(),
-		Description: data.Description.ValueString(),
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Update, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	app, err := r.gotify.Client.Application.UpdateApplication(params, r.gotify.Auth)
+	tflog.Debug(ctx, "Updating application", map[string]interface{}{"id": data.Id.ValueInt64(), "name": data.Name.ValueString()})
+
+	// Change the image first, the update response below then contains the new image URL
+	if data.Image != nil && (state.Image == nil || !data.Image.Sha256.Equal(state.Image.Sha256)) {
+		_, err := r.uploadImage(ctx, data.Id.ValueInt64(), data.Image)
+		if err != nil {
+			resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Root("image"), "Could not upload application image", err))
+			return
+		}
+	} else if data.Image == nil && state.Image != nil {
+		err := r.gotify.RemoveAppImage(ctx, data.Id.ValueInt64())
+		if err != nil {
+			resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Root("image"), "Could not remove application image", err))
+			return
+		}
+	}
+
+	// Send the request
+	app, err := r.gotify.UpdateApp(ctx, data.Id.ValueInt64(), toApplication(&data))
 	if err != nil {
-		resp.Diagnostics.AddError("Gotify API Request failed", err.Error())
+		resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Root("id"), "Gotify API Request failed", err))
 		return
 	}
 
 	// Update model with updated information
-	data.Name = types.StringValue(app.Payload.Name)
-	data.Description = types.StringValue(app.Payload.Description)
-	data.Token = types.StringValue(app.Payload.Token)
+	data.Name = types.StringValue(app.Name)
+	data.Description = types.StringValue(app.Description)
+	data.Token = storedToken(data.StoreToken, app.Token)
+	data.ImageUrl = types.StringValue(app.Image)
+	resp.Diagnostics.Append(applyDefaultPriority(app, &data)...)
 
 	// Write new data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+func toApplication(data *ApplicationResourceModel) *internal.Application {
+	return &internal.Application{
+		Application: models.Application{
+			Name:        data.Name.ValueString(),
+			Description: data.Description.ValueString(),
+		},
+		DefaultPriority: data.DefaultPriority.ValueInt64Pointer(),
+	}
+}
+
+// Older Gotify servers silently drop the default priority, so check it was actually stored.
+func applyDefaultPriority(app *internal.Application, data *ApplicationResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if app.DefaultPriority != nil {
+		data.DefaultPriority = types.Int64Value(*app.DefaultPriority)
+	} else if data.DefaultPriority.ValueInt64() != 0 {
+		diags.AddAttributeError(
+			path.Root("default_priority"),
+			"Default priority not supported by server",
+			"The Gotify server did not store the `default_priority`, it requires Gotify 2.5.0 or newer. Either upgrade the server or remove the attribute.",
+		)
+	}
+	return diags
+}
+
+func (r *ApplicationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		// Resource is being destroyed, nothing to compute
+		return
+	}
+
+	var plan, state ApplicationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	warnTokenRotation(resp, "application")
+
+	if plan.Image == nil {
+		if state.Image != nil {
+			// Removing the custom image reverts to the default one
+			plan.ImageUrl = types.StringValue(internal.DefaultApplicationImage)
+			resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		}
+		return
+	}
+
+	// Hash the image content now, so changes to the file contents show up as a diff.
+	content, err := loadImage(plan.Image)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("image"), "Could not load application image", err.Error())
+		return
+	} else if content == nil {
+		// Content is only known during apply
+		return
+	}
+	plan.Image.Sha256 = hashImage(content)
+
+	if !req.State.Raw.IsNull() && (state.Image == nil || !state.Image.Sha256.Equal(plan.Image.Sha256)) {
+		// A new image gets a new URL on the server
+		plan.ImageUrl = types.StringUnknown()
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// Reads the image content from either the local file or the inline base64 content.
+// Returns nil content if the value is not yet known.
+func loadImage(image *ApplicationImageModel) ([]byte, error) {
+	if image.Source.IsUnknown() || image.ContentBase64.IsUnknown() {
+		return nil, nil
+	}
+	if image.Source.IsNull() == image.ContentBase64.IsNull() {
+		return nil, fmt.Errorf("exactly one of `source` or `content_base64` must be set")
+	}
+	if !image.Source.IsNull() {
+		return os.ReadFile(image.Source.ValueString())
+	}
+	return base64.StdEncoding.DecodeString(image.ContentBase64.ValueString())
+}
+
+func hashImage(content []byte) types.String {
+	hash := sha256.Sum256(content)
+	return types.StringValue(hex.EncodeToString(hash[:]))
+}
+
+func (r *ApplicationResource) uploadImage(ctx context.Context, id int64, image *ApplicationImageModel) (*models.Application, error) {
+	content, err := loadImage(image)
+	if err != nil {
+		return nil, err
+	}
+	image.Sha256 = hashImage(content)
+
+	// Gotify checks the file extension, so keep the original name where possible
+	name := "image.png"
+	if !image.Source.IsNull() {
+		name = filepath.Base(image.Source.ValueString())
+	}
+
+	params := application.NewUploadAppImageParamsWithContext(ctx)
+	params.ID = id
+	params.File = runtime.NamedReader(name, bytes.NewReader(content))
+	app, err := r.gotify.Client.Application.UploadAppImage(params, r.gotify.Auth)
+	if err != nil {
+		return nil, err
+	}
+	return app.Payload, nil
+}
+
 func (r *ApplicationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var state ApplicationResourceModel
 
@@ -201,12 +431,59 @@ func (r *ApplicationResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Delete, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Deleting application", map[string]interface{}{"id": state.Id.ValueInt64()})
+
 	// Send DELETE request
-	params := application.NewDeleteAppParams()
+	params := application.NewDeleteAppParamsWithContext(ctx)
 	params.ID = state.Id.ValueInt64()
 	_, err := r.gotify.Client.Application.DeleteApp(params, r.gotify.Auth)
 	if err != nil {
-		resp.Diagnostics.AddError("Gotify API Request failed", err.Error())
+		resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Root("id"), "Gotify API Request failed", err))
 		return
 	}
 }
+
+func (r *ApplicationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Numeric IDs are used as-is, everything else is treated as the application name
+	id, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		app_list, err := r.gotify.GetApps(ctx)
+		if err != nil {
+			resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Empty(), "Gotify API Request failed", err))
+			return
+		}
+
+		found := findApplications(app_list, types.Int64Null(), types.StringValue(req.ID))
+		if len(found) != 1 {
+			resp.Diagnostics.AddError(
+				"Could not import application",
+				fmt.Sprintf("Expected exactly one application named %q, found %d. Import by numeric id instead.", req.ID, len(found)),
+			)
+			return
+		}
+		id = int64(found[0].ID)
+	}
+
+	// Read() fills in everything else
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// Finds all applications matching both the given id and name. Null values match any application.
+func findApplications(apps []*internal.Application, id types.Int64, name types.String) []*internal.Application {
+	var found []*internal.Application
+	for _, app := range apps {
+		if !id.IsNull() && app.ID != uint(id.ValueInt64()) {
+			continue
+		}
+		if !name.IsNull() && app.Name != name.ValueString() {
+			continue
+		}
+		found = append(found, app)
+	}
+	return found
+}
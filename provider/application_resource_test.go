@@ -1,13 +1,21 @@
 package provider
 
 import (
+	"fmt"
+	"terraform-provider-gotify/provider/internal"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 )
 
+// A 1x1 pixel PNG image.
+const testImagePng = "iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAYAAAAfFcSJAAAADUlEQVR42mNkYPhfDwAChwGA60e6kgAAAABJRU5ErkJggg=="
+
 func TestApplicationResource(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+	testAcc(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
@@ -24,6 +32,7 @@ resource "gotify_application" "test" {
 					resource.TestCheckResourceAttr("gotify_application.test", "description", "Test description"),
 					resource.TestCheckResourceAttrSet("gotify_application.test", "id"),
 					resource.TestCheckResourceAttrSet("gotify_application.test", "token"),
+					resource.TestCheckResourceAttr("gotify_application.test", "default_priority", "0"),
 				),
 			},
 			// Test Update() and Read()
@@ -32,13 +41,262 @@ resource "gotify_application" "test" {
 resource "gotify_application" "test" {
  name = "Changed"
  description = "Changed description"
+ default_priority = 5
 }
 `,
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("gotify_application.test", "name", "Changed"),
 					resource.TestCheckResourceAttr("gotify_application.test", "description", "Changed description"),
+					resource.TestCheckResourceAttr("gotify_application.test", "default_priority", "5"),
+				),
+			},
+			// Test ImportState() by id
+			{
+				ResourceName:      "gotify_application.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Test ImportState() by name
+			{
+				ResourceName:      "gotify_application.test",
+				ImportState:       true,
+				ImportStateId:     "Changed",
+				ImportStateVerify: true,
+			},
+			// Test image upload
+			{
+				Config: providerConfig + `
+resource "gotify_application" "test" {
+ name = "Changed"
+ description = "Changed description"
+ default_priority = 5
+ image {
+  content_base64 = "` + testImagePng + `"
+ }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("gotify_application.test", "image.sha256"),
+					resource.TestCheckResourceAttrWith("gotify_application.test", "image_url", func(value string) error {
+						if value == internal.DefaultApplicationImage {
+							return fmt.Errorf("expected a custom image, got the default %q", value)
+						}
+						return nil
+					}),
+				),
+			},
+			// Test image removal
+			{
+				Config: providerConfig + `
+resource "gotify_application" "test" {
+ name = "Changed"
+ description = "Changed description"
+ default_priority = 5
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("gotify_application.test", "image_url", internal.DefaultApplicationImage),
+					resource.TestCheckNoResourceAttr("gotify_application.test", "image.sha256"),
+				),
+			},
+		},
+	})
+}
+
+func TestApplicationResourceDrift(t *testing.T) {
+	fake := newFakeGotify(t)
+	config := providerConfig + `
+resource "gotify_application" "test" {
+ name = "Drifting"
+ description = "Managed by Terraform"
+}
+`
+	testFake(t, fake, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			// Changed in the web UI, Terraform changes it back
+			{
+				PreConfig: func() {
+					fake.mutate(func(f *fakeGotify) {
+						f.appByName("Drifting").Description = "Changed by hand"
+					})
+				},
+				Config: config,
+				Check: func(_ *terraform.State) error {
+					var description string
+					fake.mutate(func(f *fakeGotify) {
+						description = f.appByName("Drifting").Description
+					})
+					if description != "Managed by Terraform" {
+						return fmt.Errorf("expected drift to be reverted, got description %q", description)
+					}
+					return nil
+				},
+			},
+			// Deleted in the web UI, Terraform re-creates it
+			{
+				PreConfig: func() {
+					fake.mutate(func(f *fakeGotify) {
+						delete(f.apps, f.appByName("Drifting").ID)
+					})
+				},
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("gotify_application.test", "name", "Drifting"),
+					func(_ *terraform.State) error {
+						var found *fakeApp
+						fake.mutate(func(f *fakeGotify) {
+							found = f.appByName("Drifting")
+						})
+						if found == nil {
+							return fmt.Errorf("expected application to be re-created")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestApplicationResourceSharedList(t *testing.T) {
+	fake := newFakeGotify(t)
+	config := providerConfig + `
+resource "gotify_application" "test" {
+ count = 20
+ name = "Shared ${count.index}"
+}
+`
+	var before int
+	testFake(t, fake, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			// Refreshing all applications downloads the list once per Terraform command, not once per application
+			{
+				PreConfig: func() {
+					before = fake.requestCount("GET", "/application")
+				},
+				Config: config,
+				Check: func(_ *terraform.State) error {
+					if count := fake.requestCount("GET", "/application") - before; count >= 20 {
+						return fmt.Errorf("expected the application list to be shared, got %d requests", count)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestApplicationResourceWithoutToken(t *testing.T) {
+	fake := newFakeGotify(t)
+	config := providerConfig + `
+resource "gotify_application" "test" {
+ name = "Secretive"
+ description = "Managed by Terraform"
+ store_token = false
+}
+`
+	testFake(t, fake, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("gotify_application.test", "store_token", "false"),
+					resource.TestCheckNoResourceAttr("gotify_application.test", "token"),
 				),
 			},
+			// Drift is still detected without the token
+			{
+				PreConfig: func() {
+					fake.mutate(func(f *fakeGotify) {
+						f.appByName("Secretive").Description = "Changed by hand"
+					})
+				},
+				Config: config,
+				Check: func(_ *terraform.State) error {
+					var description string
+					fake.mutate(func(f *fakeGotify) {
+						description = f.appByName("Secretive").Description
+					})
+					if description != "Managed by Terraform" {
+						return fmt.Errorf("expected drift to be reverted, got description %q", description)
+					}
+					return nil
+				},
+			},
+			// Opting back in stores the token again
+			{
+				Config: providerConfig + `
+resource "gotify_application" "test" {
+ name = "Secretive"
+ description = "Managed by Terraform"
+}
+`,
+				Check: func(state *terraform.State) error {
+					var token string
+					fake.mutate(func(f *fakeGotify) {
+						token = f.appByName("Secretive").Token
+					})
+					return resource.TestCheckResourceAttr("gotify_application.test", "token", token)(state)
+				},
+			},
+		},
+	})
+}
+
+func TestApplicationResourceTokenRotation(t *testing.T) {
+	testAcc(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "gotify_application" "test" {
+ name = "Rotating"
+ rotation_triggers = {
+  leaked = "never"
+ }
+}
+`,
+			},
+			// Changing the triggers replaces the application
+			{
+				Config: providerConfig + `
+resource "gotify_application" "test" {
+ name = "Rotating"
+ rotation_triggers = {
+  leaked = "2024-06-01"
+ }
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("gotify_application.test", plancheck.ResourceActionReplace),
+						plancheck.ExpectUnknownValue("gotify_application.test", tfjsonpath.New("token")),
+					},
+				},
+			},
+			// Removing them keeps the token
+			{
+				Config: providerConfig + `
+resource "gotify_application" "test" {
+ name = "Rotating"
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("gotify_application.test", plancheck.ResourceActionUpdate),
+					},
+				},
+			},
 		},
 	})
 }
@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"terraform-provider-gotify/provider/internal"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	// Ensure implementation satisfies expected interfaces - compilition fails here otherwise.
+	_ datasource.DataSource              = &ApplicationsDataSource{}
+	_ datasource.DataSourceWithConfigure = &ApplicationsDataSource{}
+)
+
+type ApplicationsDataSource struct {
+	gotify *internal.AuthedGotifyClient
+}
+
+func NewApplicationsDataSource() datasource.DataSource {
+	return &ApplicationsDataSource{}
+}
+
+type ApplicationsDataSourceModel struct {
+	// Filters
+	NameRegex types.String `tfsdk:"name_regex"`
+	Internal  types.Bool   `tfsdk:"internal"`
+	// Options
+	StoreToken types.Bool `tfsdk:"store_token"`
+	// Read-only
+	Applications []ApplicationsDataSourceItemModel `tfsdk:"applications"`
+}
+
+type ApplicationsDataSourceItemModel struct {
+	Id              types.Int64  `tfsdk:"id"`
+	Name            types.String `tfsdk:"name"`
+	Description     types.String `tfsdk:"description"`
+	Internal        types.Bool   `tfsdk:"internal"`
+	DefaultPriority types.Int64  `tfsdk:"default_priority"`
+	ImageUrl        types.String `tfsdk:"image_url"`
+	Token           types.String `tfsdk:"token"`
+}
+
+func (d *ApplicationsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_applications"
+}
+
+func (d *ApplicationsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "Lists all applications of the configured user, optionally filtered.",
+		MarkdownDescription: "Lists all applications of the configured user. Use the optional filters to narrow down the list, all given filters must match.",
+		Attributes: map[string]schema.Attribute{
+			"name_regex":  nameRegexAttribute("applications"),
+			"store_token": storeTokenDataSourceAttribute(),
+			"internal": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Only list applications that are (or are not) internal. Internal applications are created by Gotify itself, for example by plugins.",
+			},
+			"applications": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "All applications matching the filters.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Numeric identifier of the application.",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Name of the application.",
+						},
+						"description": schema.StringAttribute{
+							Computed:    true,
+							Description: "Description of the application.",
+						},
+						"internal": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the application was created by Gotify itself.",
+						},
+						"default_priority": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Default priority of messages sent by the application. Null if the server does not support it.",
+						},
+						"image_url": schema.StringAttribute{
+							Computed:    true,
+							Description: "Path of the application image on the server, relative to the endpoint.",
+						},
+						"token": schema.StringAttribute{
+							Computed:    true,
+							Sensitive:   true,
+							Description: "The Token to both identify the sending application AND authenticate it against the server.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ApplicationsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		// IMPORTANT: This method is called MULTIPLE times. An initial call might not have configured the Provider yet, so we need
+		// to handle this gracefully. It will eventually be called with a configured provider.
+		return
+	}
+
+	client, ok := req.ProviderData.(*internal.AuthedGotifyClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *AuthedGotifyClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.gotify = client
+}
+
+func (d *ApplicationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ApplicationsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameRegex, diags := compileNameRegex(data.NameRegex)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	app_list, err := d.gotify.GetApps(ctx)
+	if err != nil {
+		resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Empty(), "Gotify API Request failed", err))
+		return
+	}
+
+	data.Applications = []ApplicationsDataSourceItemModel{}
+	for _, app := range app_list {
+		if nameRegex != nil && !nameRegex.MatchString(app.Name) {
+			continue
+		}
+		if !data.Internal.IsNull() && app.Internal != data.Internal.ValueBool() {
+			continue
+		}
+		data.Applications = append(data.Applications, ApplicationsDataSourceItemModel{
+			Id:              types.Int64Value(int64(app.ID)),
+			Name:            types.StringValue(app.Name),
+			Description:     types.StringValue(app.Description),
+			Internal:        types.BoolValue(app.Internal),
+			DefaultPriority: types.Int64PointerValue(app.DefaultPriority),
+			ImageUrl:        types.StringValue(app.Image),
+			Token:           storedToken(data.StoreToken, app.Token),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// The `store_token` attribute shared by all data sources with tokens. Data sources have no defaults, so null means
+// true, like for storeToken().
+func storeTokenDataSourceAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Optional:            true,
+		Description:         "Whether the tokens read by this data source are kept in the Terraform state. Defaults to true.",
+		MarkdownDescription: "Whether the tokens read by this data source are kept in the Terraform state. Defaults to `true`. If `false`, the tokens are null, use the ephemeral `gotify_token` resource to read them when needed.",
+	}
+}
+
+// The `name_regex` filter shared by all list data sources.
+func nameRegexAttribute(kind string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:    true,
+		Description: fmt.Sprintf("Only list %s whose name matches this regular expression (Go RE2 syntax).", kind),
+	}
+}
+
+func compileNameRegex(value types.String) (*regexp.Regexp, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if value.IsNull() {
+		return nil, diags
+	}
+	compiled, err := regexp.Compile(value.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("name_regex"), "Invalid regular expression", err.Error())
+	}
+	return compiled, diags
+}
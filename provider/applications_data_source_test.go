@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestApplicationsDataSource(t *testing.T) {
+	testAcc(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "gotify_application" "first" {
+ name = "list-first"
+}
+
+resource "gotify_application" "second" {
+ name = "list-second"
+}
+
+data "gotify_applications" "filtered" {
+ name_regex = "^list-"
+ internal = false
+ depends_on = [gotify_application.first, gotify_application.second]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.gotify_applications.filtered", "applications.#", "2"),
+					// Both are created in parallel, so their order is not known
+					resource.TestCheckTypeSetElemNestedAttrs("data.gotify_applications.filtered", "applications.*", map[string]string{
+						"name":     "list-first",
+						"internal": "false",
+					}),
+					resource.TestCheckTypeSetElemAttrPair("data.gotify_applications.filtered", "applications.*.token", "gotify_application.second", "token"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-gotify/provider/internal"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	// Ensure implementation satisfies expected interfaces - compilition fails here otherwise.
+	_ datasource.DataSource                     = &ClientDataSource{}
+	_ datasource.DataSourceWithConfigure        = &ClientDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &ClientDataSource{}
+)
+
+type ClientDataSource struct {
+	gotify *internal.AuthedGotifyClient
+}
+
+func NewClientDataSource() datasource.DataSource {
+	return &ClientDataSource{}
+}
+
+type ClientDataSourceModel struct {
+	// Lookup by either of these
+	Id         types.Int64  `tfsdk:"id"`
+	Name       types.String `tfsdk:"name"`
+	StoreToken types.Bool   `tfsdk:"store_token"`
+	// Read-only
+	Token types.String `tfsdk:"token"`
+}
+
+func (d *ClientDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_client"
+}
+
+func (d *ClientDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "Looks up an existing client, for example one managed by another Terraform configuration.",
+		MarkdownDescription: "Looks up an existing client by its `id` or its exact `name`. Exactly one client must match.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Numerical identifier of the client to look up.",
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Exact name of the client to look up.",
+			},
+			"store_token": storeTokenDataSourceAttribute(),
+			"token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The Token to both identify the reading client AND authenticate it against the server.",
+			},
+		},
+	}
+}
+
+func (d *ClientDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.AtLeastOneOf(path.MatchRoot("id"), path.MatchRoot("name")),
+	}
+}
+
+func (d *ClientDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		// IMPORTANT: This method is called MULTIPLE times. An initial call might not have configured the Provider yet, so we need
+		// to handle this gracefully. It will eventually be called with a configured provider.
+		return
+	}
+
+	client, ok := req.ProviderData.(*internal.AuthedGotifyClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *AuthedGotifyClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.gotify = client
+}
+
+func (d *ClientDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ClientDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client_list, err := d.gotify.GetClients(ctx)
+	if err != nil {
+		resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Empty(), "Gotify API Request failed", err))
+		return
+	}
+
+	found := findClients(client_list, data.Id, data.Name)
+	if len(found) == 0 {
+		resp.Diagnostics.AddError(
+			"No matching client found",
+			fmt.Sprintf("There is no client with %s. Check that it exists and is owned by the configured user.", lookupCriteria(data.Id, data.Name)),
+		)
+		return
+	} else if len(found) > 1 {
+		resp.Diagnostics.AddError(
+			"Multiple matching clients found",
+			fmt.Sprintf("There are %d clients with %s. Look up the client by its id instead.", len(found), lookupCriteria(data.Id, data.Name)),
+		)
+		return
+	}
+
+	data.Id = types.Int64Value(int64(found[0].ID))
+	data.Name = types.StringValue(found[0].Name)
+	data.Token = storedToken(data.StoreToken, found[0].Token)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestClientDataSource(t *testing.T) {
+	testAcc(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Lookup by id and by name
+			{
+				Config: providerConfig + `
+resource "gotify_client" "test" {
+ name = "Lookup"
+}
+
+data "gotify_client" "by_id" {
+ id = gotify_client.test.id
+}
+
+data "gotify_client" "by_name" {
+ name = gotify_client.test.name
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.gotify_client.by_id", "name", "gotify_client.test", "name"),
+					resource.TestCheckResourceAttrPair("data.gotify_client.by_id", "token", "gotify_client.test", "token"),
+					resource.TestCheckResourceAttrPair("data.gotify_client.by_name", "id", "gotify_client.test", "id"),
+					resource.TestCheckResourceAttrPair("data.gotify_client.by_name", "token", "gotify_client.test", "token"),
+				),
+			},
+			// Multiple matches
+			{
+				Config: providerConfig + `
+resource "gotify_client" "first" {
+ name = "Duplicate"
+}
+
+resource "gotify_client" "second" {
+ name = "Duplicate"
+}
+
+data "gotify_client" "duplicate" {
+ name = "Duplicate"
+ depends_on = [gotify_client.first, gotify_client.second]
+}
+`,
+				ExpectError: regexp.MustCompile("Multiple matching clients found"),
+			},
+		},
+	})
+}
@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"terraform-provider-gotify/provider/internal"
+
+	"github.com/gotify/go-api-client/v2/client/client"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	// Ensure implementation satisfies expected interfaces - compilition fails here otherwise.
+	_ ephemeral.EphemeralResource              = &ClientEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &ClientEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithClose     = &ClientEphemeralResource{}
+)
+
+// Key in the private data that remembers which client to delete on close.
+const clientEphemeralIdKey = "id"
+
+type ClientEphemeralResource struct {
+	gotify *internal.AuthedGotifyClient
+}
+
+func NewClientEphemeralResource() ephemeral.EphemeralResource {
+	return &ClientEphemeralResource{}
+}
+
+type ClientEphemeralResourceModel struct {
+	Name types.String `tfsdk:"name"`
+	// Read-only after open
+	Id    types.Int64  `tfsdk:"id"`
+	Token types.String `tfsdk:"token"`
+}
+
+func (r *ClientEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_client"
+}
+
+func (r *ClientEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "A short-lived Gotify client that only exists while Terraform runs.",
+		MarkdownDescription: "A short-lived Gotify client that only exists while Terraform runs. The client is created when Terraform opens the ephemeral resource and deleted again when it is closed, so its `token` is never written to the state or plan.\n\nUse it to fetch messages from within the run, for example to verify a deployment. For long-lived clients, use the `gotify_client` resource instead.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Numerical identifier of this specific client.",
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name for the client. Will show up in the clients list UI while Terraform runs.",
+			},
+			"token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The Token to both identify the reading client AND authenticate it against the server.",
+			},
+		},
+	}
+}
+
+func (r *ClientEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		// IMPORTANT: This method is called MULTIPLE times. An initial call might not have configured the Provider yet, so we need
+		// to handle this gracefully. It will eventually be called with a configured provider.
+		return
+	}
+
+	client, ok := req.ProviderData.(*internal.AuthedGotifyClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *AuthedGotifyClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.gotify = client
+}
+
+func (r *ClientEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data ClientEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	new_client, err := createClient(ctx, r.gotify, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Root("name"), "Gotify API Request failed", err))
+		return
+	}
+
+	// Close() only gets the private data, remember which client to delete
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, clientEphemeralIdKey, []byte(strconv.FormatUint(uint64(new_client.ID), 10)))...)
+	if resp.Diagnostics.HasError() {
+		// Close() would not know about the client, don't leave it behind
+		if err := deleteClient(ctx, r.gotify, int64(new_client.ID)); err != nil {
+			resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Empty(), fmt.Sprintf("Could not delete client %d, delete it by hand", new_client.ID), err))
+		}
+		return
+	}
+
+	data.Id = types.Int64Value(int64(new_client.ID))
+	data.Token = types.StringValue(new_client.Token)
+	data.Name = types.StringValue(new_client.Name)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+func (r *ClientEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	raw, diags := req.Private.GetKey(ctx, clientEphemeralIdKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || raw == nil {
+		return
+	}
+
+	id, err := strconv.ParseInt(string(raw), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Could not close ephemeral client",
+			fmt.Sprintf("Expected a numeric client id in the private data, got %q. Please report this issue to the provider developers.", raw),
+		)
+		return
+	}
+
+	// Deleted by hand in the meantime, nothing left to clean up
+	var notFound *client.DeleteClientNotFound
+	if err := deleteClient(ctx, r.gotify, id); err != nil && !errors.As(err, &notFound) {
+		resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Empty(), "Gotify API Request failed", err))
+		return
+	}
+}
@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// Ephemeral values can't be checked directly, the echo provider copies them into its state.
+const clientEphemeralConfig = providerConfig + `
+ephemeral "gotify_client" "test" {
+ name = "Ephemeral"
+}
+
+provider "echo" {
+ data = ephemeral.gotify_client.test
+}
+
+resource "echo" "test" {}
+`
+
+var testAccEchoProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"gotify": testAccProtoV6ProviderFactories["gotify"],
+	"echo":   echoprovider.NewProviderServer(),
+}
+
+func TestClientEphemeralResource(t *testing.T) {
+	testAcc(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccEchoProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		Steps: []resource.TestStep{
+			// Test Open()
+			{
+				Config: clientEphemeralConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("echo.test", "data.name", "Ephemeral"),
+					resource.TestCheckResourceAttrSet("echo.test", "data.id"),
+					resource.TestCheckResourceAttrSet("echo.test", "data.token"),
+				),
+			},
+		},
+	})
+}
+
+func TestClientEphemeralResourceClose(t *testing.T) {
+	fake := newFakeGotify(t)
+	testFake(t, fake, resource.TestCase{
+		ProtoV6ProviderFactories: testAccEchoProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		Steps: []resource.TestStep{
+			// Close() deletes the client again once Terraform is done
+			{
+				Config: clientEphemeralConfig,
+				Check: func(_ *terraform.State) error {
+					var found *fakeClient
+					fake.mutate(func(f *fakeGotify) {
+						found = f.clientByName("Ephemeral")
+					})
+					if found != nil {
+						return fmt.Errorf("expected ephemeral client %d to be deleted", found.ID)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
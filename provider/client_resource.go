@@ -3,21 +3,27 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"terraform-provider-gotify/provider/internal"
 
 	"github.com/gotify/go-api-client/v2/client/client"
 	"github.com/gotify/go-api-client/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var (
 	// Ensure implementation satisfies expected interfaces - compilition fails here otherwise.
-	_ resource.Resource              = &ClientResource{}
-	_ resource.ResourceWithConfigure = &ClientResource{}
+	_ resource.Resource                = &ClientResource{}
+	_ resource.ResourceWithConfigure   = &ClientResource{}
+	_ resource.ResourceWithImportState = &ClientResource{}
+	_ resource.ResourceWithModifyPlan  = &ClientResource{}
 )
 
 type ClientResource struct {
@@ -29,7 +35,10 @@ func NewClientResource() resource.Resource {
 }
 
 type ClientResourceModel struct {
-	Name types.String `tfsdk:"name"`
+	Name             types.String   `tfsdk:"name"`
+	RotationTriggers types.Map      `tfsdk:"rotation_triggers"`
+	StoreToken       types.Bool     `tfsdk:"store_token"`
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
 	// Read-only after apply
 	Id    types.Int64  `tfsdk:"id"`
 	Token types.String `tfsdk:"token"`
@@ -42,7 +51,7 @@ func (r *ClientResource) Metadata(ctx context.Context, req resource.MetadataRequ
 func (r *ClientResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description:         "A Gotify client is used to fetch messages from the server to display them in a client application.",
-		MarkdownDescription: "After applying the client, use the `token` to fetch messages in your client.",
+		MarkdownDescription: "After applying the client, use the `token` to fetch messages in your client.\n\nExisting clients can be imported by their numeric `id` or by their exact `name`.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.Int64Attribute{
 				Computed: true,
@@ -60,6 +69,11 @@ func (r *ClientResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Sensitive:   true,
 				Description: "The Token to both identify the reading client AND authenticate it against the server.",
 			},
+			"rotation_triggers": rotationTriggersAttribute("client"),
+			"store_token":       storeTokenAttribute("client"),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Update: true, Delete: true}),
 		},
 	}
 }
@@ -93,19 +107,22 @@ func (r *ClientResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	params := client.NewCreateClientParams()
-	params.Body = &models.Client{
-		Name: data.Name.ValueString(),
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	new_client, err := r.gotify.Client.Client.CreateClient(params, r.gotify.Auth)
+	tflog.Debug(ctx, "Creating client", map[string]interface{}{"name": data.Name.ValueString()})
+
+	new_client, err := createClient(ctx, r.gotify, data.Name.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Gotify API Request failed", err.Error())
+		resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Root("name"), "Gotify API Request failed", err))
 		return
 	}
 
-	data.Id = types.Int64Value(int64(new_client.Payload.ID))
-	data.Token = types.StringValue(new_client.Payload.Token)
-	data.Name = types.StringValue(new_client.Payload.Name)
+	data.Id = types.Int64Value(int64(new_client.ID))
+	data.Token = storedToken(data.StoreToken, new_client.Token)
+	data.Name = types.StringValue(new_client.Name)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -118,25 +135,26 @@ func (r *ClientResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	params := client.NewGetClientsParams()
-	client_list, err := r.gotify.Client.Client.GetClients(params, r.gotify.Auth)
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Reading client", map[string]interface{}{"id": state.Id.ValueInt64()})
+
+	client_list, err := r.gotify.GetClients(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Gotify API Request failed", err.Error())
+		resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Root("id"), "Gotify API Request failed", err))
 		return
 	}
 
 	// Find this application and it's data
-	var found *models.Client
-	for _, client := range client_list.Payload {
-		if client.ID == uint(state.Id.ValueInt64()) {
-			found = client
-			break
-		}
-	}
-	if found != nil {
+	if matches := findClients(client_list, state.Id, types.StringNull()); len(matches) > 0 {
+		found := matches[0]
 		// Update information on state
 		state.Name = types.StringValue(found.Name)
-		state.Token = types.StringValue(found.Token)
+		state.StoreToken = types.BoolValue(storeToken(state.StoreToken))
+		state.Token = storedToken(state.StoreToken, found.Token)
 
 		// Write new information to tf-state
 		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
@@ -155,19 +173,26 @@ func (r *ClientResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	params := client.NewUpdateClientParams()
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Update, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Updating client", map[string]interface{}{"id": data.Id.ValueInt64(), "name": data.Name.ValueString()})
+
+	params := client.NewUpdateClientParamsWithContext(ctx)
 	params.ID = data.Id.ValueInt64()
 	params.Body = &models.Client{
 		Name: data.Name.ValueString(),
 	}
 	updated_client, err := r.gotify.Client.Client.UpdateClient(params, r.gotify.Auth)
 	if err != nil {
-		resp.Diagnostics.AddError("Gotify API Request failed", err.Error())
+		resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Root("id"), "Gotify API Request failed", err))
 		return
 	}
 
 	data.Name = types.StringValue(updated_client.Payload.Name)
-	data.Token = types.StringValue(updated_client.Payload.Token)
+	data.Token = storedToken(data.StoreToken, updated_client.Payload.Token)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -180,11 +205,80 @@ func (r *ClientResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	params := client.NewDeleteClientParams()
-	params.ID = state.Id.ValueInt64()
-	_, err := r.gotify.Client.Client.DeleteClient(params, r.gotify.Auth)
-	if err != nil {
-		resp.Diagnostics.AddError("Gotify API Request failed", err.Error())
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Delete, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
 		return
 	}
+	tflog.Debug(ctx, "Deleting client", map[string]interface{}{"id": state.Id.ValueInt64()})
+
+	if err := deleteClient(ctx, r.gotify, state.Id.ValueInt64()); err != nil {
+		resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Root("id"), "Gotify API Request failed", err))
+		return
+	}
+}
+
+func (r *ClientResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	warnTokenRotation(resp, "client")
+}
+
+func (r *ClientResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Numeric IDs are used as-is, everything else is treated as the client name
+	id, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		client_list, err := r.gotify.GetClients(ctx)
+		if err != nil {
+			resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Empty(), "Gotify API Request failed", err))
+			return
+		}
+
+		found := findClients(client_list, types.Int64Null(), types.StringValue(req.ID))
+		if len(found) != 1 {
+			resp.Diagnostics.AddError(
+				"Could not import client",
+				fmt.Sprintf("Expected exactly one client named %q, found %d. Import by numeric id instead.", req.ID, len(found)),
+			)
+			return
+		}
+		id = int64(found[0].ID)
+	}
+
+	// Read() fills in everything else
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// Finds all clients matching both the given id and name. Null values match any client.
+func findClients(clients []*models.Client, id types.Int64, name types.String) []*models.Client {
+	var found []*models.Client
+	for _, client := range clients {
+		if !id.IsNull() && client.ID != uint(id.ValueInt64()) {
+			continue
+		}
+		if !name.IsNull() && client.Name != name.ValueString() {
+			continue
+		}
+		found = append(found, client)
+	}
+	return found
+}
+
+// Creates a new client, shared with the ephemeral client.
+func createClient(ctx context.Context, gotify *internal.AuthedGotifyClient, name string) (*models.Client, error) {
+	params := client.NewCreateClientParamsWithContext(ctx)
+	params.Body = &models.Client{
+		Name: name,
+	}
+	new_client, err := gotify.Client.Client.CreateClient(params, gotify.Auth)
+	if err != nil {
+		return nil, err
+	}
+	return new_client.Payload, nil
+}
+
+// Deletes the client, shared with the ephemeral client.
+func deleteClient(ctx context.Context, gotify *internal.AuthedGotifyClient, id int64) error {
+	params := client.NewDeleteClientParamsWithContext(ctx)
+	params.ID = id
+	_, err := gotify.Client.Client.DeleteClient(params, gotify.Auth)
+	return err
 }
@@ -1,13 +1,17 @@
 package provider
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 )
 
 func TestClientResource(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+	testAcc(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
@@ -35,6 +39,104 @@ resource "gotify_client" "test" {
 					resource.TestCheckResourceAttr("gotify_client.test", "name", "Changed"),
 				),
 			},
+			// Test ImportState() by id
+			{
+				ResourceName:      "gotify_client.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Test ImportState() by name
+			{
+				ResourceName:      "gotify_client.test",
+				ImportState:       true,
+				ImportStateId:     "Changed",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestClientResourceDrift(t *testing.T) {
+	fake := newFakeGotify(t)
+	config := providerConfig + `
+resource "gotify_client" "test" {
+ name = "Drifting"
+}
+`
+	testFake(t, fake, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			// Renamed in the web UI, Terraform renames it back
+			{
+				PreConfig: func() {
+					fake.mutate(func(f *fakeGotify) {
+						f.clientByName("Drifting").Name = "Renamed by hand"
+					})
+				},
+				Config: config,
+				Check: func(_ *terraform.State) error {
+					var found *fakeClient
+					fake.mutate(func(f *fakeGotify) {
+						found = f.clientByName("Drifting")
+					})
+					if found == nil {
+						return fmt.Errorf("expected drift to be reverted")
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestClientResourceTokenRotation(t *testing.T) {
+	testAcc(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "gotify_client" "test" {
+ name = "Rotating"
+}
+`,
+			},
+			// Adopting the triggers keeps the token
+			{
+				Config: providerConfig + `
+resource "gotify_client" "test" {
+ name = "Rotating"
+ rotation_triggers = {
+  leaked = "never"
+ }
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("gotify_client.test", plancheck.ResourceActionUpdate),
+					},
+				},
+			},
+			// Changing them replaces the client
+			{
+				Config: providerConfig + `
+resource "gotify_client" "test" {
+ name = "Rotating"
+ rotation_triggers = {
+  leaked = "2024-06-01"
+ }
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("gotify_client.test", plancheck.ResourceActionReplace),
+						plancheck.ExpectUnknownValue("gotify_client.test", tfjsonpath.New("token")),
+					},
+				},
+			},
 		},
 	})
 }
@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-gotify/provider/internal"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	// Ensure implementation satisfies expected interfaces - compilition fails here otherwise.
+	_ datasource.DataSource              = &ClientsDataSource{}
+	_ datasource.DataSourceWithConfigure = &ClientsDataSource{}
+)
+
+type ClientsDataSource struct {
+	gotify *internal.AuthedGotifyClient
+}
+
+func NewClientsDataSource() datasource.DataSource {
+	return &ClientsDataSource{}
+}
+
+type ClientsDataSourceModel struct {
+	// Filters
+	NameRegex types.String `tfsdk:"name_regex"`
+	// Options
+	StoreToken types.Bool `tfsdk:"store_token"`
+	// Read-only
+	Clients []ClientsDataSourceItemModel `tfsdk:"clients"`
+}
+
+type ClientsDataSourceItemModel struct {
+	Id    types.Int64  `tfsdk:"id"`
+	Name  types.String `tfsdk:"name"`
+	Token types.String `tfsdk:"token"`
+}
+
+func (d *ClientsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_clients"
+}
+
+func (d *ClientsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists all clients of the configured user, optionally filtered by name.",
+		Attributes: map[string]schema.Attribute{
+			"name_regex":  nameRegexAttribute("clients"),
+			"store_token": storeTokenDataSourceAttribute(),
+			"clients": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "All clients matching the filters.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Numerical identifier of the client.",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Name of the client.",
+						},
+						"token": schema.StringAttribute{
+							Computed:    true,
+							Sensitive:   true,
+							Description: "The Token to both identify the reading client AND authenticate it against the server.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ClientsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		// IMPORTANT: This method is called MULTIPLE times. An initial call might not have configured the Provider yet, so we need
+		// to handle this gracefully. It will eventually be called with a configured provider.
+		return
+	}
+
+	client, ok := req.ProviderData.(*internal.AuthedGotifyClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *AuthedGotifyClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.gotify = client
+}
+
+func (d *ClientsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ClientsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameRegex, diags := compileNameRegex(data.NameRegex)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client_list, err := d.gotify.GetClients(ctx)
+	if err != nil {
+		resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Empty(), "Gotify API Request failed", err))
+		return
+	}
+
+	data.Clients = []ClientsDataSourceItemModel{}
+	for _, client := range client_list {
+		if nameRegex != nil && !nameRegex.MatchString(client.Name) {
+			continue
+		}
+		data.Clients = append(data.Clients, ClientsDataSourceItemModel{
+			Id:    types.Int64Value(int64(client.ID)),
+			Name:  types.StringValue(client.Name),
+			Token: storedToken(data.StoreToken, client.Token),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestClientsDataSource(t *testing.T) {
+	testAcc(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "gotify_client" "first" {
+ name = "list-first"
+}
+
+resource "gotify_client" "second" {
+ name = "list-second"
+}
+
+data "gotify_clients" "filtered" {
+ name_regex = "^list-"
+ depends_on = [gotify_client.first, gotify_client.second]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.gotify_clients.filtered", "clients.#", "2"),
+					// Both are created in parallel, so their order is not known
+					resource.TestCheckTypeSetElemNestedAttrs("data.gotify_clients.filtered", "clients.*", map[string]string{"name": "list-first"}),
+					resource.TestCheckTypeSetElemAttrPair("data.gotify_clients.filtered", "clients.*.token", "gotify_client.second", "token"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-gotify/provider/internal"
+
+	"github.com/gotify/go-api-client/v2/client/user"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	// Ensure implementation satisfies expected interfaces - compilition fails here otherwise.
+	_ datasource.DataSource              = &CurrentUserDataSource{}
+	_ datasource.DataSourceWithConfigure = &CurrentUserDataSource{}
+)
+
+type CurrentUserDataSource struct {
+	gotify *internal.AuthedGotifyClient
+}
+
+func NewCurrentUserDataSource() datasource.DataSource {
+	return &CurrentUserDataSource{}
+}
+
+type CurrentUserDataSourceModel struct {
+	// Read-only
+	Id    types.Int64  `tfsdk:"id"`
+	Name  types.String `tfsdk:"name"`
+	Admin types.Bool   `tfsdk:"admin"`
+}
+
+func (d *CurrentUserDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_current_user"
+}
+
+func (d *CurrentUserDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "The user the provider is authenticated as.",
+		MarkdownDescription: "The user the provider is authenticated as. Use it in preconditions, for example to require an admin before managing other users.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Numeric identifier of the user.",
+			},
+			"name": schema.StringAttribute{
+				Computed:    true,
+				Description: "Name of the user, which is also the username used to log in.",
+			},
+			"admin": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the user has administrative rights, allowing them to manage other users.",
+			},
+		},
+	}
+}
+
+func (d *CurrentUserDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		// IMPORTANT: This method is called MULTIPLE times. An initial call might not have configured the Provider yet, so we need
+		// to handle this gracefully. It will eventually be called with a configured provider.
+		return
+	}
+
+	client, ok := req.ProviderData.(*internal.AuthedGotifyClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *AuthedGotifyClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.gotify = client
+}
+
+func (d *CurrentUserDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CurrentUserDataSourceModel
+
+	params := user.NewCurrentUserParamsWithContext(ctx)
+	current, err := d.gotify.Client.User.CurrentUser(params, d.gotify.Auth)
+	if err != nil {
+		resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Empty(), "Gotify API Request failed", err))
+		return
+	}
+
+	data.Id = types.Int64Value(int64(current.Payload.ID))
+	data.Name = types.StringValue(current.Payload.Name)
+	data.Admin = types.BoolValue(current.Payload.Admin)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
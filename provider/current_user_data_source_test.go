@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestCurrentUserDataSource(t *testing.T) {
+	testAcc(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "gotify_current_user" "test" {
+ lifecycle {
+  postcondition {
+   condition     = self.admin
+   error_message = "Expected the provider to be configured with an admin"
+  }
+ }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.gotify_current_user.test", "name", "admin"),
+					resource.TestCheckResourceAttr("data.gotify_current_user.test", "admin", "true"),
+					resource.TestCheckResourceAttrSet("data.gotify_current_user.test", "id"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,205 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-gotify/provider/internal"
+
+	"github.com/gotify/go-api-client/v2/client/user"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	// Ensure implementation satisfies expected interfaces - compilition fails here otherwise.
+	_ resource.Resource              = &CurrentUserPasswordResource{}
+	_ resource.ResourceWithConfigure = &CurrentUserPasswordResource{}
+)
+
+type CurrentUserPasswordResource struct {
+	gotify *internal.AuthedGotifyClient
+}
+
+func NewCurrentUserPasswordResource() resource.Resource {
+	return &CurrentUserPasswordResource{}
+}
+
+type CurrentUserPasswordResourceModel struct {
+	PassWo        types.String   `tfsdk:"pass_wo"`
+	PassWoVersion types.Int64    `tfsdk:"pass_wo_version"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+	// Read-only after apply
+	Id types.Int64 `tfsdk:"id"`
+}
+
+func (r *CurrentUserPasswordResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_current_user_password"
+}
+
+func (r *CurrentUserPasswordResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "Changes the password of the user the provider is authenticated as. Requires Terraform 1.11 or newer.",
+		MarkdownDescription: "Changes the password of the user the provider is authenticated as, for example to lock down the default `admin` user right after the server is set up. Requires Terraform 1.11 or newer.\n\nThe provider uses the new password for all requests that follow during the same run. Make resources that should only be created afterwards depend on this resource. Later runs must configure the provider with the new password, for example through the `GOTIFY_PASSWORD` environment variable.\n\nRemoving the resource does not change the password back.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+				Description: "Numeric identifier of the user the password was changed for.",
+			},
+			"pass_wo": schema.StringAttribute{
+				Required:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				Description:         "The new password. Write-only, it is never stored in the Terraform state.",
+				MarkdownDescription: "The new password. Write-only, it is never stored in the Terraform plan or state. Changes to it are not detected, change `pass_wo_version` to send a new password.",
+			},
+			"pass_wo_version": schema.Int64Attribute{
+				Optional:            true,
+				Description:         "Change this to send a new pass_wo to the server.",
+				MarkdownDescription: "Change this to send a new `pass_wo` to the server. Terraform can not detect changes to write-only values on its own.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Update: true}),
+		},
+	}
+}
+
+func (r *CurrentUserPasswordResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		// IMPORTANT: This method is called MULTIPLE times. An initial call might not have configured the Provider yet, so we need
+		// to handle this gracefully. It will eventually be called with a configured provider.
+		return
+	}
+
+	client, ok := req.ProviderData.(*internal.AuthedGotifyClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AuthedGotifyClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.gotify = client
+}
+
+func (r *CurrentUserPasswordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data CurrentUserPasswordResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	// Write-only values are only in the config, never in the plan
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("pass_wo"), &data.PassWo)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Changing the password of the current user")
+
+	err := r.gotify.UpdateCurrentUserPassword(ctx, data.PassWo.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Root("pass_wo"), "Could not change password", err))
+		return
+	}
+
+	// Already authenticates with the new password
+	data.Id, err = r.currentUserId(ctx)
+	if err != nil {
+		resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Empty(), "Gotify API Request failed", err))
+		return
+	}
+	data.PassWo = types.StringNull()
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CurrentUserPasswordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state CurrentUserPasswordResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Reading the current user", map[string]interface{}{"id": state.Id.ValueInt64()})
+
+	// The password can't be read back, only who it belongs to
+	id, err := r.currentUserId(ctx)
+	if err != nil {
+		resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Empty(), "Gotify API Request failed", err))
+		return
+	}
+	state.Id = id
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *CurrentUserPasswordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data CurrentUserPasswordResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	// Write-only values are only in the config, never in the plan
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("pass_wo"), &data.PassWo)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Update, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Changing the password of the current user")
+
+	err := r.gotify.UpdateCurrentUserPassword(ctx, data.PassWo.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Root("pass_wo"), "Could not change password", err))
+		return
+	}
+
+	// Already authenticates with the new password
+	data.Id, err = r.currentUserId(ctx)
+	if err != nil {
+		resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Empty(), "Gotify API Request failed", err))
+		return
+	}
+	data.PassWo = types.StringNull()
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CurrentUserPasswordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.AddWarning(
+		"Password is not changed back",
+		"This will only remove the password from your Terraform State. The user keeps the current password, the provider must still be configured with it.",
+	)
+}
+
+func (r *CurrentUserPasswordResource) currentUserId(ctx context.Context) (types.Int64, error) {
+	params := user.NewCurrentUserParamsWithContext(ctx)
+	current, err := r.gotify.Client.User.CurrentUser(params, r.gotify.Auth)
+	if err != nil {
+		return types.Int64Null(), err
+	}
+	return types.Int64Value(int64(current.Payload.ID)), nil
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestCurrentUserPasswordResource(t *testing.T) {
+	// Runs against the fake only, changing the password of the real server breaks all other tests
+	fake := newFakeGotify(t)
+	t.Setenv("GOTIFY_PASSWORD", "admin")
+	checkPass := func(expected string) resource.TestCheckFunc {
+		return func(_ *terraform.State) error {
+			var pass string
+			fake.mutate(func(f *fakeGotify) {
+				pass = f.userByName("admin").Pass
+			})
+			if pass != expected {
+				return fmt.Errorf("expected password %q on the server, got %q", expected, pass)
+			}
+			return nil
+		}
+	}
+	testFake(t, fake, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			// Test Create(), the provider keeps working with the new password during the same run
+			{
+				Config: `
+provider "gotify" {
+ username = "admin"
+}
+
+resource "gotify_current_user_password" "test" {
+ pass_wo = "locked-down"
+}
+
+resource "gotify_application" "test" {
+ name = "After lock down"
+ depends_on = [gotify_current_user_password.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("gotify_current_user_password.test", "id"),
+					resource.TestCheckNoResourceAttr("gotify_current_user_password.test", "pass_wo"),
+					resource.TestCheckResourceAttrSet("gotify_application.test", "id"),
+					checkPass("locked-down"),
+					// Later runs configure the new password
+					func(_ *terraform.State) error {
+						t.Setenv("GOTIFY_PASSWORD", "locked-down")
+						return nil
+					},
+				),
+			},
+			// Test Update() by bumping the version
+			{
+				Config: `
+provider "gotify" {
+ username = "admin"
+}
+
+resource "gotify_current_user_password" "test" {
+ pass_wo = "locked-down-again"
+ pass_wo_version = 2
+}
+
+resource "gotify_application" "test" {
+ name = "After lock down"
+ depends_on = [gotify_current_user_password.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					checkPass("locked-down-again"),
+					func(_ *terraform.State) error {
+						t.Setenv("GOTIFY_PASSWORD", "locked-down-again")
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
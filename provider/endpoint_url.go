@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Joins the path onto the endpoint, keeping any base path Gotify is served under. Query and fragment are dropped.
+// With websocket set, the scheme is converted to "ws" or "wss" respectively.
+func endpointURL(endpoint string, path string, websocket bool) (*url.URL, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("endpoint is not a valid URL: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("endpoint must be an absolute URL starting with http:// or https://, got %q", endpoint)
+	}
+
+	if websocket {
+		if u.Scheme == "https" {
+			u.Scheme = "wss"
+		} else {
+			u.Scheme = "ws"
+		}
+	}
+	u.Path = strings.TrimRight(u.Path, "/") + path
+	u.RawPath = ""
+	u.RawQuery = ""
+	u.Fragment = ""
+	return u, nil
+}
+
+// Sets the token as the only query parameter, which Gotify accepts instead of the "X-Gotify-Key" header.
+func withToken(u *url.URL, token string) string {
+	u.RawQuery = url.Values{"token": []string{token}}.Encode()
+	return u.String()
+}
@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestEndpointURL(t *testing.T) {
+	tests := []struct {
+		endpoint  string
+		path      string
+		websocket bool
+		expected  string
+	}{
+		{"http://gotify.local", "/message", false, "http://gotify.local/message"},
+		{"https://gotify.local/", "/message", false, "https://gotify.local/message"},
+		{"https://example.com/gotify//", "/message", false, "https://example.com/gotify/message"},
+		{"https://example.com:8443/gotify?foo=bar#top", "/message", false, "https://example.com:8443/gotify/message"},
+		{"http://gotify.local", "/stream", true, "ws://gotify.local/stream"},
+		{"https://example.com/gotify/", "/stream", true, "wss://example.com/gotify/stream"},
+	}
+	for _, test := range tests {
+		u, err := endpointURL(test.endpoint, test.path, test.websocket)
+		if err != nil {
+			t.Errorf("Unexpected error for %q: %v", test.endpoint, err.Error())
+			continue
+		}
+		if u.String() != test.expected {
+			t.Errorf("Expected %q for %q, got %q", test.expected, test.endpoint, u.String())
+		}
+	}
+}
+
+func TestEndpointURLInvalid(t *testing.T) {
+	for _, endpoint := range []string{"gotify.local", "/gotify", "ftp://gotify.local", "ws://gotify.local", "http://"} {
+		if _, err := endpointURL(endpoint, "/message", false); err == nil {
+			t.Errorf("Expected an error for endpoint %q, got none", endpoint)
+		}
+	}
+}
+
+func TestWithToken(t *testing.T) {
+	u, err := endpointURL("https://gotify.local/base", "/message", false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err.Error())
+	}
+	if actual := withToken(u, "a&b"); actual != "https://gotify.local/base/message?token=a%26b" {
+		t.Errorf("Expected the token to be escaped, got %q", actual)
+	}
+}
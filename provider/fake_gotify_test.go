@@ -0,0 +1,617 @@
+package provider
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"terraform-provider-gotify/provider/internal"
+	"testing"
+	"time"
+
+	"github.com/gotify/go-api-client/v2/models"
+	"gopkg.in/yaml.v3"
+)
+
+// Module path of the plugin every fake server has installed, same as the one bundled in the docker-compose image.
+const fakePluginModulePath = "github.com/LukasKnuth/gotify-slack-webhook"
+
+// An in-process stand-in for the Gotify REST API, keeping all state in memory.
+//
+// It implements the endpoints this provider uses, with the same authentication rules and error responses as Gotify.
+// Tests can inject faults (errors, latency) and change objects behind the providers back to simulate drift.
+type fakeGotify struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	nextId   uint
+	users    map[uint]*fakeUser
+	apps     map[uint]*fakeApp
+	clients  map[uint]*fakeClient
+	plugins  map[uint]*fakePlugin
+	messages map[uint]*models.MessageExternal
+	// Fault injection
+	faults       []*fakeFault
+	latency      time.Duration
+	databaseDown bool
+	// Every request, formatted as "METHOD /path"
+	requests []string
+}
+
+type fakeUser struct {
+	models.UserExternal
+	Pass string
+}
+
+type fakeApp struct {
+	internal.Application
+	UserId uint
+}
+
+type fakeClient struct {
+	models.Client
+	UserId uint
+}
+
+type fakePlugin struct {
+	models.PluginConfExternal
+	UserId uint
+	Config string
+}
+
+type fakeFault struct {
+	method string
+	path   string
+	status int
+	times  int
+}
+
+// Starts a new fake server with just the default "admin" user and the slack webhook plugin.
+// The server is closed automatically when the test finishes.
+func newFakeGotify(t *testing.T) *fakeGotify {
+	f := &fakeGotify{
+		users:    map[uint]*fakeUser{},
+		apps:     map[uint]*fakeApp{},
+		clients:  map[uint]*fakeClient{},
+		plugins:  map[uint]*fakePlugin{},
+		messages: map[uint]*models.MessageExternal{},
+	}
+	admin := f.addUser("admin", "admin", true)
+	id := f.newId()
+	f.plugins[id] = &fakePlugin{
+		PluginConfExternal: models.PluginConfExternal{
+			ID:           id,
+			Name:         "Slack Webhook",
+			Token:        fakeToken("P"),
+			ModulePath:   fakePluginModulePath,
+			Author:       "Lukas Knuth",
+			Website:      "https://github.com/LukasKnuth/gotify-slack-webhook",
+			License:      "MIT",
+			Capabilities: []string{"webhooker", "configurer", "displayer"},
+		},
+		UserId: admin.ID,
+		Config: "channels: []\n",
+	}
+
+	f.Server = httptest.NewServer(http.HandlerFunc(f.serveHTTP))
+	t.Cleanup(f.Close)
+	return f
+}
+
+// Lets the next requests matching method and path fail with the given status, the given number of times.
+func (f *fakeGotify) failNext(method string, path string, status int, times int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.faults = append(f.faults, &fakeFault{method: method, path: path, status: status, times: times})
+}
+
+// Delays every following response by the given duration.
+func (f *fakeGotify) setLatency(latency time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.latency = latency
+}
+
+// Changes the servers state directly, like a user clicking around in the web UI would.
+func (f *fakeGotify) mutate(change func(f *fakeGotify)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	change(f)
+}
+
+// Number of requests received so far that match method and path.
+func (f *fakeGotify) requestCount(method string, path string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	count := 0
+	for _, request := range f.requests {
+		if request == method+" "+path {
+			count++
+		}
+	}
+	return count
+}
+
+func (f *fakeGotify) appByName(name string) *fakeApp {
+	for _, app := range f.apps {
+		if app.Name == name {
+			return app
+		}
+	}
+	return nil
+}
+
+func (f *fakeGotify) clientByName(name string) *fakeClient {
+	for _, client := range f.clients {
+		if client.Name == name {
+			return client
+		}
+	}
+	return nil
+}
+
+func (f *fakeGotify) userByName(name string) *fakeUser {
+	for _, user := range f.users {
+		if user.Name == name {
+			return user
+		}
+	}
+	return nil
+}
+
+func (f *fakeGotify) newId() uint {
+	f.nextId++
+	return f.nextId
+}
+
+func (f *fakeGotify) addUser(name string, pass string, admin bool) *fakeUser {
+	user := &fakeUser{UserExternal: models.UserExternal{ID: f.newId(), Name: name, Admin: admin}, Pass: pass}
+	f.users[user.ID] = user
+	return user
+}
+
+func fakeToken(prefix string) string {
+	random := make([]byte, 7)
+	_, _ = rand.Read(random)
+	return prefix + hex.EncodeToString(random)
+}
+
+func (f *fakeGotify) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.requests = append(f.requests, req.Method+" "+req.URL.Path)
+	if f.latency > 0 {
+		time.Sleep(f.latency)
+	}
+	for _, fault := range f.faults {
+		if fault.times > 0 && fault.method == req.Method && fault.path == req.URL.Path {
+			fault.times--
+			fakeError(w, fault.status, "injected fault")
+			return
+		}
+	}
+
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	route := req.Method + " /" + segments[0]
+	var id uint
+	if segments[0] == "current" {
+		// The only routes without an id
+		route = req.Method + " " + req.URL.Path
+	} else {
+		if len(segments) > 1 {
+			parsed, err := strconv.ParseUint(segments[1], 10, 64)
+			if err != nil {
+				fakeError(w, http.StatusBadRequest, "invalid id")
+				return
+			}
+			id = uint(parsed)
+			route += "/{id}"
+		}
+		if len(segments) > 2 {
+			route += "/" + strings.Join(segments[2:], "/")
+		}
+	}
+
+	// Unauthenticated endpoints
+	switch route {
+	case "GET /health":
+		if f.databaseDown {
+			fakeJSON(w, http.StatusInternalServerError, map[string]string{"health": "orange", "database": "red"})
+		} else {
+			fakeJSON(w, http.StatusOK, map[string]string{"health": "green", "database": "green"})
+		}
+		return
+	case "GET /version":
+		fakeJSON(w, http.StatusOK, models.VersionInfo{Version: "2.5.0", Commit: "fake", BuildDate: "2024-01-01T00:00:00Z"})
+		return
+	case "POST /message":
+		f.createMessage(w, req)
+		return
+	}
+
+	user := f.authenticate(req)
+	if user == nil {
+		fakeError(w, http.StatusUnauthorized, "you need to provide a valid access token or user credentials to access this api")
+		return
+	}
+
+	switch route {
+	case "GET /application":
+		apps := []*internal.Application{}
+		for _, app := range f.sortedApps(user) {
+			apps = append(apps, &app.Application)
+		}
+		fakeJSON(w, http.StatusOK, apps)
+	case "POST /application":
+		var body internal.Application
+		if !fakeDecode(w, req, &body) {
+			return
+		}
+		app := &fakeApp{Application: body, UserId: user.ID}
+		app.ID = f.newId()
+		app.Token = fakeToken("A")
+		app.Image = internal.DefaultApplicationImage
+		if app.DefaultPriority == nil {
+			app.DefaultPriority = new(int64)
+		}
+		f.apps[app.ID] = app
+		fakeJSON(w, http.StatusOK, app.Application)
+	case "PUT /application/{id}":
+		app := f.apps[id]
+		if app == nil || app.UserId != user.ID {
+			fakeError(w, http.StatusNotFound, "app with id doesn't exist")
+			return
+		}
+		var body internal.Application
+		if !fakeDecode(w, req, &body) {
+			return
+		}
+		app.Name = body.Name
+		app.Description = body.Description
+		if body.DefaultPriority != nil {
+			app.DefaultPriority = body.DefaultPriority
+		}
+		fakeJSON(w, http.StatusOK, app.Application)
+	case "DELETE /application/{id}":
+		app := f.apps[id]
+		if app == nil || app.UserId != user.ID {
+			fakeError(w, http.StatusNotFound, "app with id doesn't exist")
+			return
+		}
+		delete(f.apps, id)
+		for messageId, message := range f.messages {
+			if message.ApplicationID == id {
+				delete(f.messages, messageId)
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	case "POST /application/{id}/image":
+		app := f.apps[id]
+		if app == nil || app.UserId != user.ID {
+			fakeError(w, http.StatusNotFound, "app with id doesn't exist")
+			return
+		}
+		if _, _, err := req.FormFile("file"); err != nil {
+			fakeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		app.Image = "image/" + fakeToken("") + ".png"
+		fakeJSON(w, http.StatusOK, app.Application)
+	case "DELETE /application/{id}/image":
+		app := f.apps[id]
+		if app == nil || app.UserId != user.ID {
+			fakeError(w, http.StatusNotFound, "app with id doesn't exist")
+			return
+		}
+		app.Image = internal.DefaultApplicationImage
+		w.WriteHeader(http.StatusOK)
+	case "GET /application/{id}/message":
+		f.getAppMessages(w, req, user, id)
+	case "DELETE /message/{id}":
+		message := f.messages[id]
+		if message == nil || f.apps[message.ApplicationID] == nil || f.apps[message.ApplicationID].UserId != user.ID {
+			fakeError(w, http.StatusNotFound, "message does not exist")
+			return
+		}
+		delete(f.messages, id)
+		w.WriteHeader(http.StatusOK)
+	case "GET /client":
+		clients := []*models.Client{}
+		for _, client := range f.sortedClients(user) {
+			clients = append(clients, &client.Client)
+		}
+		fakeJSON(w, http.StatusOK, clients)
+	case "POST /client":
+		var body models.Client
+		if !fakeDecode(w, req, &body) {
+			return
+		}
+		client := &fakeClient{Client: models.Client{ID: f.newId(), Name: body.Name, Token: fakeToken("C")}, UserId: user.ID}
+		f.clients[client.ID] = client
+		fakeJSON(w, http.StatusOK, client.Client)
+	case "PUT /client/{id}":
+		client := f.clients[id]
+		if client == nil || client.UserId != user.ID {
+			fakeError(w, http.StatusNotFound, "client with id doesn't exist")
+			return
+		}
+		var body models.Client
+		if !fakeDecode(w, req, &body) {
+			return
+		}
+		client.Name = body.Name
+		fakeJSON(w, http.StatusOK, client.Client)
+	case "DELETE /client/{id}":
+		client := f.clients[id]
+		if client == nil || client.UserId != user.ID {
+			fakeError(w, http.StatusNotFound, "client with id doesn't exist")
+			return
+		}
+		delete(f.clients, id)
+		w.WriteHeader(http.StatusOK)
+	case "GET /plugin":
+		plugins := []*models.PluginConfExternal{}
+		for _, plugin := range f.plugins {
+			if plugin.UserId == user.ID {
+				plugins = append(plugins, &plugin.PluginConfExternal)
+			}
+		}
+		sort.Slice(plugins, func(i, j int) bool { return plugins[i].ID < plugins[j].ID })
+		fakeJSON(w, http.StatusOK, plugins)
+	case "POST /plugin/{id}/enable", "POST /plugin/{id}/disable":
+		plugin := f.plugins[id]
+		if plugin == nil || plugin.UserId != user.ID {
+			fakeError(w, http.StatusNotFound, "plugin not found")
+			return
+		}
+		enable := strings.HasSuffix(route, "enable")
+		if plugin.Enabled == enable {
+			fakeError(w, http.StatusBadRequest, "plugin is already in the requested state")
+			return
+		}
+		plugin.Enabled = enable
+		w.WriteHeader(http.StatusOK)
+	case "GET /plugin/{id}/config":
+		plugin := f.plugins[id]
+		if plugin == nil || plugin.UserId != user.ID {
+			fakeError(w, http.StatusNotFound, "plugin not found")
+			return
+		}
+		w.Header().Set("Content-Type", "application/x-yaml")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, plugin.Config)
+	case "POST /plugin/{id}/config":
+		plugin := f.plugins[id]
+		if plugin == nil || plugin.UserId != user.ID {
+			fakeError(w, http.StatusNotFound, "plugin not found")
+			return
+		}
+		body, _ := io.ReadAll(req.Body)
+		var parsed interface{}
+		if err := yaml.Unmarshal(body, &parsed); err != nil {
+			fakeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		plugin.Config = string(body)
+		w.WriteHeader(http.StatusOK)
+	case "GET /current/user":
+		fakeJSON(w, http.StatusOK, user.UserExternal)
+	case "POST /current/user/password":
+		var body models.UserExternalPass
+		if !fakeDecode(w, req, &body) {
+			return
+		}
+		user.Pass = body.Pass
+		w.WriteHeader(http.StatusOK)
+	default:
+		f.serveUsers(w, req, user, route, id)
+	}
+}
+
+// User management requires an admin.
+func (f *fakeGotify) serveUsers(w http.ResponseWriter, req *http.Request, user *fakeUser, route string, id uint) {
+	if !strings.HasPrefix(route, req.Method+" /user") {
+		fakeError(w, http.StatusNotFound, "page not found")
+		return
+	}
+	if !user.Admin {
+		fakeError(w, http.StatusForbidden, "you are not allowed to access this api")
+		return
+	}
+
+	switch route {
+	case "GET /user":
+		users := []*models.UserExternal{}
+		for _, user := range f.users {
+			users = append(users, &user.UserExternal)
+		}
+		sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+		fakeJSON(w, http.StatusOK, users)
+	case "POST /user":
+		var body models.UserExternalWithPass
+		if !fakeDecode(w, req, &body) {
+			return
+		}
+		if f.userByName(body.Name) != nil {
+			fakeError(w, http.StatusBadRequest, "username already exists")
+			return
+		}
+		created := f.addUser(body.Name, body.Pass, body.Admin)
+		fakeJSON(w, http.StatusOK, created.UserExternal)
+	case "GET /user/{id}":
+		found := f.users[id]
+		if found == nil {
+			fakeError(w, http.StatusNotFound, "user does not exist")
+			return
+		}
+		fakeJSON(w, http.StatusOK, found.UserExternal)
+	case "POST /user/{id}":
+		found := f.users[id]
+		if found == nil {
+			fakeError(w, http.StatusNotFound, "user does not exist")
+			return
+		}
+		var body models.UserExternalWithPass
+		if !fakeDecode(w, req, &body) {
+			return
+		}
+		found.Name = body.Name
+		found.Admin = body.Admin
+		if body.Pass != "" {
+			found.Pass = body.Pass
+		}
+		fakeJSON(w, http.StatusOK, found.UserExternal)
+	case "DELETE /user/{id}":
+		if f.users[id] == nil {
+			fakeError(w, http.StatusNotFound, "user does not exist")
+			return
+		}
+		delete(f.users, id)
+		w.WriteHeader(http.StatusOK)
+	default:
+		fakeError(w, http.StatusNotFound, "page not found")
+	}
+}
+
+// Either basic auth, or a client token in the "X-Gotify-Key" header or "token" query parameter.
+func (f *fakeGotify) authenticate(req *http.Request) *fakeUser {
+	if name, pass, ok := req.BasicAuth(); ok {
+		user := f.userByName(name)
+		if user != nil && user.Pass == pass {
+			return user
+		}
+		return nil
+	}
+	token := req.Header.Get("X-Gotify-Key")
+	if token == "" {
+		token = req.URL.Query().Get("token")
+	}
+	for _, client := range f.clients {
+		if token != "" && client.Token == token {
+			return f.users[client.UserId]
+		}
+	}
+	return nil
+}
+
+// Messages are published with an application token instead of user credentials.
+func (f *fakeGotify) createMessage(w http.ResponseWriter, req *http.Request) {
+	token := req.Header.Get("X-Gotify-Key")
+	if token == "" {
+		token = req.URL.Query().Get("token")
+	}
+	var app *fakeApp
+	for _, candidate := range f.apps {
+		if token != "" && candidate.Token == token {
+			app = candidate
+		}
+	}
+	if app == nil {
+		fakeError(w, http.StatusUnauthorized, "you need to provide a valid access token or user credentials to access this api")
+		return
+	}
+
+	var body internal.Message
+	if !fakeDecode(w, req, &body) {
+		return
+	}
+	message := &models.MessageExternal{
+		ID:            f.newId(),
+		ApplicationID: app.ID,
+		Title:         body.Title,
+		Message:       body.Message,
+		Extras:        body.Extras,
+		Date:          time.Now().UTC().Truncate(time.Second),
+	}
+	if message.Title == "" {
+		message.Title = app.Name
+	}
+	if body.Priority != nil {
+		message.Priority = int(*body.Priority)
+	} else if app.DefaultPriority != nil {
+		message.Priority = int(*app.DefaultPriority)
+	}
+	f.messages[message.ID] = message
+	fakeJSON(w, http.StatusOK, message)
+}
+
+func (f *fakeGotify) getAppMessages(w http.ResponseWriter, req *http.Request, user *fakeUser, id uint) {
+	app := f.apps[id]
+	if app == nil || app.UserId != user.ID {
+		fakeError(w, http.StatusNotFound, "app with id doesn't exist")
+		return
+	}
+	limit, err := strconv.Atoi(req.URL.Query().Get("limit"))
+	if err != nil {
+		limit = 100
+	}
+	since, _ := strconv.ParseUint(req.URL.Query().Get("since"), 10, 64)
+
+	// Newest first
+	messages := []*models.MessageExternal{}
+	for _, message := range f.messages {
+		if message.ApplicationID == id && (since == 0 || message.ID < uint(since)) {
+			messages = append(messages, message)
+		}
+	}
+	sort.Slice(messages, func(i, j int) bool { return messages[i].ID > messages[j].ID })
+	if len(messages) > limit {
+		messages = messages[:limit]
+	}
+	fakeJSON(w, http.StatusOK, models.PagedMessages{
+		Paging:   models.Paging{Size: len(messages), Since: uint(since), Limit: limit},
+		Messages: messages,
+	})
+}
+
+func (f *fakeGotify) sortedApps(user *fakeUser) []*fakeApp {
+	apps := []*fakeApp{}
+	for _, app := range f.apps {
+		if app.UserId == user.ID {
+			apps = append(apps, app)
+		}
+	}
+	sort.Slice(apps, func(i, j int) bool { return apps[i].ID < apps[j].ID })
+	return apps
+}
+
+func (f *fakeGotify) sortedClients(user *fakeUser) []*fakeClient {
+	clients := []*fakeClient{}
+	for _, client := range f.clients {
+		if client.UserId == user.ID {
+			clients = append(clients, client)
+		}
+	}
+	sort.Slice(clients, func(i, j int) bool { return clients[i].ID < clients[j].ID })
+	return clients
+}
+
+func fakeDecode(w http.ResponseWriter, req *http.Request, body interface{}) bool {
+	if err := json.NewDecoder(req.Body).Decode(body); err != nil {
+		fakeError(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+	return true
+}
+
+func fakeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// Same format Gotify uses for all its errors.
+func fakeError(w http.ResponseWriter, status int, description string) {
+	fakeJSON(w, status, models.Error{
+		Error:            http.StatusText(status),
+		ErrorCode:        status,
+		ErrorDescription: description,
+	})
+}
@@ -0,0 +1,50 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+)
+
+// Sends a JSON request to endpoints (or with fields) the generated API client does not know about.
+// The response is decoded into result, unless it is nil.
+func (c *AuthedGotifyClient) submitJSON(ctx context.Context, id string, method string, pathPattern string, pathParams map[string]string, body interface{}, result interface{}) error {
+	return c.submit(ctx, c.Auth, id, method, pathPattern, runtime.JSONMime, pathParams, body, result)
+}
+
+// Like submitJSON, but encodes the request body with the given media type and authenticates with the given auth info.
+func (c *AuthedGotifyClient) submit(ctx context.Context, auth runtime.ClientAuthInfoWriter, id string, method string, pathPattern string, bodyMediaType string, pathParams map[string]string, body interface{}, result interface{}) error {
+	_, err := c.Client.Transport.Submit(&runtime.ClientOperation{
+		ID:                 id,
+		Method:             method,
+		PathPattern:        pathPattern,
+		ProducesMediaTypes: []string{runtime.JSONMime},
+		ConsumesMediaTypes: []string{bodyMediaType},
+		Schemes:            []string{"http", "https"},
+		Params: runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest, _ strfmt.Registry) error {
+			for name, value := range pathParams {
+				if err := r.SetPathParam(name, value); err != nil {
+					return err
+				}
+			}
+			if body != nil {
+				return r.SetBodyParam(body)
+			}
+			return nil
+		}),
+		Reader: runtime.ClientResponseReaderFunc(func(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+			if response.Code() < 200 || response.Code() >= 300 {
+				return nil, runtime.NewAPIError(id, fmt.Sprintf("[%s %s] %s", method, pathPattern, response.Message()), response.Code())
+			}
+			if result != nil {
+				return nil, consumer.Consume(response.Body(), result)
+			}
+			return nil, nil
+		}),
+		AuthInfo: auth,
+		Context:  ctx,
+	})
+	return err
+}
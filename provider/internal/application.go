@@ -0,0 +1,28 @@
+package internal
+
+import (
+	"context"
+
+	"github.com/go-openapi/swag"
+	"github.com/gotify/go-api-client/v2/models"
+)
+
+// Gotify application, including fields the generated API client does not know about yet.
+type Application struct {
+	models.Application
+	// Added in Gotify 2.5.0. Older servers don't report it, so it stays nil.
+	DefaultPriority *int64 `json:"defaultPriority,omitempty"`
+}
+
+func (c *AuthedGotifyClient) CreateApp(ctx context.Context, app *Application) (*Application, error) {
+	var created Application
+	err := c.submitJSON(ctx, "createApp", "POST", "/application", nil, app, &created)
+	return &created, err
+}
+
+func (c *AuthedGotifyClient) UpdateApp(ctx context.Context, id int64, app *Application) (*Application, error) {
+	var updated Application
+	params := map[string]string{"id": swag.FormatInt64(id)}
+	err := c.submitJSON(ctx, "updateApplication", "PUT", "/application/{id}", params, app, &updated)
+	return &updated, err
+}
@@ -0,0 +1,17 @@
+package internal
+
+import (
+	"context"
+
+	"github.com/go-openapi/swag"
+)
+
+// The default image Gotify reports for applications without a custom image.
+const DefaultApplicationImage = "static/defaultapp.png"
+
+// Removes the custom image of the given application, reverting it to the default image.
+// The generated API client does not offer this endpoint, so the request is built by hand.
+func (c *AuthedGotifyClient) RemoveAppImage(ctx context.Context, id int64) error {
+	params := map[string]string{"id": swag.FormatInt64(id)}
+	return c.submitJSON(ctx, "removeAppImage", "DELETE", "/application/{id}/image", params, nil, nil)
+}
@@ -0,0 +1,45 @@
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRemoveAppImage(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodDelete {
+			t.Errorf("Expected method %q, got %q", http.MethodDelete, req.Method)
+		}
+		if req.URL.Path != "/application/42/image" {
+			t.Errorf("Expected path %q, got %q", "/application/42/image", req.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	gotify, err := NewAuthedClient(ts.URL, testCredentials, ClientOptions{})
+	if err != nil {
+		t.Fatalf("Could not construct client: %v", err.Error())
+	}
+
+	if err := gotify.RemoveAppImage(t.Context(), 42); err != nil {
+		t.Fatalf("Error during test request: %v", err.Error())
+	}
+}
+
+func TestRemoveAppImageError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	gotify, err := NewAuthedClient(ts.URL, testCredentials, ClientOptions{})
+	if err != nil {
+		t.Fatalf("Could not construct client: %v", err.Error())
+	}
+
+	if err := gotify.RemoveAppImage(t.Context(), 42); err == nil {
+		t.Fatal("Expected an error for a 404 response, got none")
+	}
+}
@@ -0,0 +1,35 @@
+package internal
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetAppsDefaultPriority(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, `[{"id": 1, "name": "new", "defaultPriority": 5}, {"id": 2, "name": "old"}]`)
+	}))
+	defer ts.Close()
+
+	gotify, err := NewAuthedClient(ts.URL, testCredentials, ClientOptions{})
+	if err != nil {
+		t.Fatalf("Could not construct client: %v", err.Error())
+	}
+
+	apps, err := gotify.GetApps(t.Context())
+	if err != nil {
+		t.Fatalf("Error during test request: %v", err.Error())
+	}
+	if len(apps) != 2 {
+		t.Fatalf("Expected 2 apps, got %d", len(apps))
+	}
+	if apps[0].DefaultPriority == nil || *apps[0].DefaultPriority != 5 {
+		t.Errorf("Expected default priority 5, got %v", apps[0].DefaultPriority)
+	}
+	if apps[1].DefaultPriority != nil {
+		t.Errorf("Expected no default priority from older server, got %v", *apps[1].DefaultPriority)
+	}
+}
@@ -3,16 +3,28 @@ package internal
 import (
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/go-openapi/runtime"
+	httptransport "github.com/go-openapi/runtime/client"
 	"github.com/gotify/go-api-client/v2/auth"
 	"github.com/gotify/go-api-client/v2/client"
-	"github.com/gotify/go-api-client/v2/gotify"
 )
 
 type AuthedGotifyClient struct {
 	Client *client.GotifyREST
 	Auth   runtime.ClientAuthInfoWriter
+	// Same as Auth, to change the credentials during the run.
+	auth      *currentAuth
+	inventory *inventory
+	// Only set with WaitForReady.
+	ready *ReadyTransport
+}
+
+// Whether requests are sent right away, rather than waiting for the server to become ready first. Requests which are
+// optional during plan should be skipped otherwise, the server might only be deployed during the apply.
+func (c *AuthedGotifyClient) Ready() bool {
+	return c.ready == nil || c.ready.Ready()
 }
 
 type OverwriteHostTransport struct {
@@ -29,19 +41,70 @@ func wrapWithHost(host string, wrap http.RoundTripper) http.RoundTripper {
 	return &OverwriteHostTransport{Host: host, Next: wrap}
 }
 
-func NewAuthedClient(endpoint string, username string, password string, host *string) (*AuthedGotifyClient, error) {
+// Credentials to authenticate against the Gotify API with.
+// Either Username and Password OR a ClientToken are set, never both.
+type Credentials struct {
+	Username    string
+	Password    string
+	ClientToken string
+}
+
+func (c Credentials) authInfo() runtime.ClientAuthInfoWriter {
+	if c.ClientToken != "" {
+		// Sent as the "X-Gotify-Key" header
+		return auth.TokenAuth(c.ClientToken)
+	}
+	return auth.BasicAuth(c.Username, c.Password)
+}
+
+// Optional settings for how the client connects to the Gotify server.
+type ClientOptions struct {
+	// Overwrites the Host header in all requests, if set.
+	HostHeader *string
+	TLS        TLSConfig
+	// How often failed requests are retried, zero disables retries.
+	MaxRetries int
+	// The longest time to wait between two retries.
+	RetryMaxWait time.Duration
+	// How long a request may take including its retries, zero means no limit.
+	RequestTimeout time.Duration
+	// Waits for the server to become ready before the first request, if set.
+	WaitForReady *WaitForReady
+}
+
+func NewAuthedClient(endpoint string, credentials Credentials, options ClientOptions) (*AuthedGotifyClient, error) {
 	url, err := url.Parse(endpoint)
 	if err != nil {
 		return nil, err
 	}
 
-	transport := http.DefaultTransport
-	if host != nil {
-		transport = wrapWithHost(*host, transport)
+	tlsConfig, err := options.TLS.build()
+	if err != nil {
+		return nil, err
 	}
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	base.TLSClientConfig = tlsConfig
 
-	client := gotify.NewClient(url, &http.Client{Transport: transport})
-	auth := auth.BasicAuth(username, password)
+	inventory := &inventory{}
+	var transport http.RoundTripper = wrapWithInventory(inventory, wrapWithLogging(base))
+	if options.MaxRetries > 0 {
+		transport = wrapWithRetry(options.MaxRetries, options.RetryMaxWait, transport)
+	}
+	if options.RequestTimeout > 0 {
+		transport = wrapWithTimeout(options.RequestTimeout, transport)
+	}
+	if options.HostHeader != nil {
+		transport = wrapWithHost(*options.HostHeader, transport)
+	}
+	var ready *ReadyTransport
+	if options.WaitForReady != nil {
+		ready = wrapWithReady(url.JoinPath("health").String(), *options.WaitForReady, transport)
+		transport = ready
+	}
 
-	return &AuthedGotifyClient{Client: client, Auth: auth}, nil
+	// Same as gotify.NewClient, but without the fixed timeout per request and with readable errors
+	rest := httptransport.NewWithClient(url.Host, url.Path, []string{url.Scheme}, &http.Client{Transport: transport})
+	client := client.New(&errorTransport{next: &contextTransport{next: rest}}, nil)
+	auth := newCurrentAuth(credentials)
+	return &AuthedGotifyClient{Client: client, Auth: auth, auth: auth, inventory: inventory, ready: ready}, nil
 }
@@ -16,6 +16,8 @@ const (
 	localHost = "127.0.0.1"
 )
 
+var testCredentials = Credentials{Username: "test", Password: "test"}
+
 func TestClientHostOverwrite(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		if req.Host != testHost {
@@ -29,7 +31,7 @@ func TestClientHostOverwrite(t *testing.T) {
 	defer ts.Close()
 
 	host := testHost
-	gotify, err := NewAuthedClient(ts.URL, "test", "test", &host)
+	gotify, err := NewAuthedClient(ts.URL, testCredentials, ClientOptions{HostHeader: &host})
 	if err != nil {
 		t.Fatalf("Could not construct client: %v", err.Error())
 	}
@@ -53,7 +55,60 @@ func TestClientHostDefault(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	gotify, err := NewAuthedClient(ts.URL, "test", "test", nil)
+	gotify, err := NewAuthedClient(ts.URL, testCredentials, ClientOptions{})
+	if err != nil {
+		t.Fatalf("Could not construct client: %v", err.Error())
+	}
+
+	params := application.NewGetAppsParams()
+	_, err = gotify.Client.Application.GetApps(params, gotify.Auth)
+	if err != nil {
+		t.Fatalf("Error during test request: %v", err.Error())
+	}
+}
+
+func TestClientBasicAuth(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		username, password, ok := req.BasicAuth()
+		if !ok || username != "test" || password != "test" {
+			t.Errorf("Expected basic auth with \"test\"/\"test\", got %q/%q", username, password)
+		}
+		if key := req.Header.Get("X-Gotify-Key"); key != "" {
+			t.Errorf("Expected no \"X-Gotify-Key\" header, got %q", key)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, "[]")
+	}))
+	defer ts.Close()
+
+	gotify, err := NewAuthedClient(ts.URL, testCredentials, ClientOptions{})
+	if err != nil {
+		t.Fatalf("Could not construct client: %v", err.Error())
+	}
+
+	params := application.NewGetAppsParams()
+	_, err = gotify.Client.Application.GetApps(params, gotify.Auth)
+	if err != nil {
+		t.Fatalf("Error during test request: %v", err.Error())
+	}
+}
+
+func TestClientTokenAuth(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if key := req.Header.Get("X-Gotify-Key"); key != "t0k3n" {
+			t.Errorf("Expected \"X-Gotify-Key\" to be %q, got %q", "t0k3n", key)
+		}
+		if _, _, ok := req.BasicAuth(); ok {
+			t.Error("Expected no basic auth when using a client token")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, "[]")
+	}))
+	defer ts.Close()
+
+	gotify, err := NewAuthedClient(ts.URL, Credentials{ClientToken: "t0k3n"}, ClientOptions{})
 	if err != nil {
 		t.Fatalf("Could not construct client: %v", err.Error())
 	}
@@ -0,0 +1,50 @@
+package internal
+
+import (
+	"context"
+	"sync"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/gotify/go-api-client/v2/client/user"
+	"github.com/gotify/go-api-client/v2/models"
+)
+
+// Authenticates requests with the current credentials, which change when the password of the current user is changed.
+type currentAuth struct {
+	lock        sync.RWMutex
+	credentials Credentials
+	writer      runtime.ClientAuthInfoWriter
+}
+
+func newCurrentAuth(credentials Credentials) *currentAuth {
+	return &currentAuth{credentials: credentials, writer: credentials.authInfo()}
+}
+
+func (a *currentAuth) AuthenticateRequest(req runtime.ClientRequest, reg strfmt.Registry) error {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	return a.writer.AuthenticateRequest(req, reg)
+}
+
+// Changes the password of the authenticated user.
+// Requests started afterwards authenticate with the new password, so the rest of the run keeps working.
+func (c *AuthedGotifyClient) UpdateCurrentUserPassword(ctx context.Context, pass string) error {
+	// Hold back all other requests until they can use the new password
+	c.auth.lock.Lock()
+	defer c.auth.lock.Unlock()
+
+	params := user.NewUpdateCurrentUserParamsWithContext(ctx)
+	params.Body = &models.UserExternalPass{Pass: pass}
+	_, err := c.Client.User.UpdateCurrentUser(params, c.auth.writer)
+	if err != nil {
+		return err
+	}
+
+	// Client tokens stay valid when the password changes
+	if c.auth.credentials.ClientToken == "" {
+		c.auth.credentials.Password = pass
+		c.auth.writer = c.auth.credentials.authInfo()
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gotify/go-api-client/v2/client/application"
+)
+
+// Serves the password endpoint and checks all other requests authenticate with the current password.
+func testPasswordServer(t *testing.T, password *string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, pass, ok := req.BasicAuth()
+		if req.Header.Get("X-Gotify-Key") != "client-token" && (!ok || pass != *password) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if req.URL.Path == "/current/user/password" {
+			var body map[string]string
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				t.Fatalf("Could not decode request body: %v", err.Error())
+			}
+			*password = body["pass"]
+			fmt.Fprintln(w, "{}")
+		} else {
+			fmt.Fprintln(w, "[]")
+		}
+	}))
+}
+
+func TestUpdateCurrentUserPassword(t *testing.T) {
+	password := testCredentials.Password
+	ts := testPasswordServer(t, &password)
+	defer ts.Close()
+
+	gotify, err := NewAuthedClient(ts.URL, testCredentials, ClientOptions{})
+	if err != nil {
+		t.Fatalf("Could not construct client: %v", err.Error())
+	}
+
+	err = gotify.UpdateCurrentUserPassword(t.Context(), "changed")
+	if err != nil {
+		t.Fatalf("Error during test request: %v", err.Error())
+	}
+	if password != "changed" {
+		t.Errorf("Expected the password to be changed, got %q", password)
+	}
+
+	// Later requests authenticate with the new password
+	params := application.NewGetAppsParams()
+	_, err = gotify.Client.Application.GetApps(params, gotify.Auth)
+	if err != nil {
+		t.Fatalf("Expected requests to use the new password: %v", err.Error())
+	}
+}
+
+func TestUpdateCurrentUserPasswordWithToken(t *testing.T) {
+	password := "old"
+	ts := testPasswordServer(t, &password)
+	defer ts.Close()
+
+	gotify, err := NewAuthedClient(ts.URL, Credentials{ClientToken: "client-token"}, ClientOptions{})
+	if err != nil {
+		t.Fatalf("Could not construct client: %v", err.Error())
+	}
+
+	err = gotify.UpdateCurrentUserPassword(t.Context(), "changed")
+	if err != nil {
+		t.Fatalf("Error during test request: %v", err.Error())
+	}
+
+	// The client token is still used
+	params := application.NewGetAppsParams()
+	_, err = gotify.Client.Application.GetApps(params, gotify.Auth)
+	if err != nil {
+		t.Fatalf("Expected requests to keep using the client token: %v", err.Error())
+	}
+}
@@ -0,0 +1,150 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/go-openapi/runtime"
+	"github.com/gotify/go-api-client/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// Proxies might answer with entire HTML pages, only this much of an error response is kept.
+const maxErrorBody = 512
+
+// Failed request to the Gotify API, with the details from the JSON body Gotify sends along.
+type APIError struct {
+	// Like "POST /application".
+	Operation  string
+	StatusCode int
+	// Gotify's "error" and "errorDescription", like "Forbidden" and "you are not allowed to access this api".
+	// For responses that don't come from Gotify, like from a proxy in front of it, the status text and the body.
+	Name        string
+	Description string
+	// Returned by the generated API client, so errors.As keeps working with its types.
+	err error
+}
+
+func (e *APIError) Error() string {
+	message := fmt.Sprintf("[%s] %d %s", e.Operation, e.StatusCode, e.Name)
+	if e.Description != "" {
+		message += ": " + e.Description
+	}
+	return message
+}
+
+func (e *APIError) Unwrap() error {
+	return e.err
+}
+
+func newAPIError(operation string, status int, body []byte, err error) *APIError {
+	apiErr := &APIError{Operation: operation, StatusCode: status, Name: http.StatusText(status), err: err}
+	var payload models.Error
+	if json.Unmarshal(body, &payload) == nil && payload.Error != "" {
+		apiErr.Name = payload.Error
+		apiErr.Description = payload.ErrorDescription
+	} else {
+		apiErr.Description = strings.TrimSpace(string(body))
+	}
+	return apiErr
+}
+
+// Turns all failed responses into an APIError, for both the generated API client and requests built by hand.
+type errorTransport struct {
+	next runtime.ClientTransport
+}
+
+func (et *errorTransport) Submit(operation *runtime.ClientOperation) (interface{}, error) {
+	reader := operation.Reader
+	name := fmt.Sprintf("%s %s", operation.Method, operation.PathPattern)
+	operation.Reader = runtime.ClientResponseReaderFunc(func(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+		if response.Code() < 400 {
+			return reader.ReadResponse(response, consumer)
+		}
+
+		// The reader consumes the body, keep it for the error
+		body, err := io.ReadAll(io.LimitReader(response.Body(), maxErrorBody))
+		if err != nil {
+			return nil, err
+		}
+		result, err := reader.ReadResponse(&bufferedResponse{ClientResponse: response, body: body}, consumer)
+		if err == nil {
+			// Some endpoints report with error codes, like "/health" when the server is unhealthy
+			return result, nil
+		}
+		return nil, newAPIError(name, response.Code(), body, err)
+	})
+	return et.next.Submit(operation)
+}
+
+type bufferedResponse struct {
+	runtime.ClientResponse
+	body []byte
+}
+
+func (br *bufferedResponse) Body() io.ReadCloser {
+	return io.NopCloser(bytes.NewReader(br.body))
+}
+
+// Turns the error of a request to Gotify into a diagnostic, which says what went wrong and how to fix it.
+//
+// The diagnostic points at the given attribute, unless the provider configuration or the server is at fault.
+// Errors which are not specific to any status code keep the given summary.
+func ErrorDiagnostic(attribute path.Path, summary string, err error) diag.Diagnostic {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return diag.NewErrorDiagnostic(
+			"Gotify did not respond in time",
+			"The request to Gotify took too long and was canceled. Increase `request_timeout` of the provider if single requests are slow, or the `timeouts` block of the resource if the whole operation is.\n\n"+err.Error(),
+		)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return newDiagnostic(attribute, summary, err.Error())
+	}
+
+	response := fmt.Sprintf("Gotify responded to %s with: %d %s", apiErr.Operation, apiErr.StatusCode, apiErr.Name)
+	if apiErr.Description != "" {
+		response += ", " + apiErr.Description
+	}
+	switch {
+	case apiErr.StatusCode == http.StatusUnauthorized:
+		return diag.NewErrorDiagnostic(
+			"Gotify rejected the credentials",
+			"Check the username and password or the client token of the provider, either in its configuration or the `GOTIFY_USERNAME`, `GOTIFY_PASSWORD` and `GOTIFY_CLIENT_TOKEN` environment variables. The password might have been changed outside of Terraform, or the client token was deleted.\n\n"+response,
+		)
+	case apiErr.StatusCode == http.StatusForbidden:
+		return newDiagnostic(
+			attribute,
+			"Not allowed by Gotify",
+			"The user the provider is authenticated as is not allowed to do this. Managing users requires an admin, check the `admin` attribute of the `gotify_current_user` data source.\n\n"+response,
+		)
+	case apiErr.StatusCode == http.StatusNotFound:
+		return newDiagnostic(
+			attribute,
+			"Not found in Gotify",
+			"The object does not exist (anymore), it was probably deleted outside of Terraform. Run `terraform apply -refresh-only` to remove deleted objects from the state, or check the configured identifier.\n\n"+response,
+		)
+	case apiErr.StatusCode >= 500:
+		return diag.NewErrorDiagnostic(
+			"Gotify server error",
+			"The Gotify server failed to handle the request, this is usually temporary. Try again, or increase `max_retries` of the provider to retry automatically. Check the server log if it keeps failing.\n\n"+response,
+		)
+	default:
+		return newDiagnostic(attribute, summary, response)
+	}
+}
+
+func newDiagnostic(attribute path.Path, summary string, detail string) diag.Diagnostic {
+	if attribute.Equal(path.Empty()) {
+		return diag.NewErrorDiagnostic(summary, detail)
+	}
+	return diag.NewAttributeErrorDiagnostic(attribute, summary, detail)
+}
@@ -0,0 +1,104 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gotify/go-api-client/v2/client/application"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func TestAPIErrorFromGotify(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintln(w, `{"error": "Not Found", "errorCode": 404, "errorDescription": "app with id 42 doesn't exists"}`)
+	}))
+	defer ts.Close()
+
+	gotify, err := NewAuthedClient(ts.URL, testCredentials, ClientOptions{})
+	if err != nil {
+		t.Fatalf("Could not construct client: %v", err.Error())
+	}
+
+	params := application.NewDeleteAppParamsWithContext(t.Context())
+	params.ID = 42
+	_, err = gotify.Client.Application.DeleteApp(params, gotify.Auth)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected an APIError, got %T: %v", err, err)
+	}
+	if apiErr.Operation != "DELETE /application/{id}" || apiErr.StatusCode != 404 || apiErr.Name != "Not Found" || apiErr.Description != "app with id 42 doesn't exists" {
+		t.Errorf("Unexpected APIError %+v", apiErr)
+	}
+	// The errors of the generated API client are still there
+	var notFound *application.DeleteAppNotFound
+	if !errors.As(err, &notFound) || notFound.Payload.ErrorDescription != apiErr.Description {
+		t.Errorf("Expected the generated error to be wrapped, got %v", errors.Unwrap(err))
+	}
+}
+
+func TestAPIErrorFromProxy(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		fmt.Fprint(w, "upstream not ready")
+	}))
+	defer ts.Close()
+
+	gotify, err := NewAuthedClient(ts.URL, testCredentials, ClientOptions{})
+	if err != nil {
+		t.Fatalf("Could not construct client: %v", err.Error())
+	}
+
+	_, err = gotify.GetApps(t.Context())
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected an APIError, got %T: %v", err, err)
+	}
+	if apiErr.StatusCode != 502 || apiErr.Name != "Bad Gateway" || apiErr.Description != "upstream not ready" {
+		t.Errorf("Unexpected APIError %+v", apiErr)
+	}
+}
+
+func TestErrorDiagnostic(t *testing.T) {
+	attribute := path.Root("id")
+	apiErr := func(status int) error {
+		return &APIError{Operation: "GET /test", StatusCode: status, Name: http.StatusText(status), Description: "from gotify"}
+	}
+
+	cases := []struct {
+		err       error
+		summary   string
+		attribute bool
+	}{
+		{apiErr(401), "Gotify rejected the credentials", false},
+		{apiErr(403), "Not allowed by Gotify", true},
+		{apiErr(404), "Not found in Gotify", true},
+		{apiErr(400), "Could not test", true},
+		{apiErr(503), "Gotify server error", false},
+		{fmt.Errorf("request failed: %w", context.DeadlineExceeded), "Gotify did not respond in time", false},
+		{errors.New("connection refused"), "Could not test", true},
+	}
+	for _, c := range cases {
+		d := ErrorDiagnostic(attribute, "Could not test", c.err)
+		if d.Summary() != c.summary {
+			t.Errorf("Expected summary %q for %v, got %q", c.summary, c.err, d.Summary())
+		}
+		_, withAttribute := d.(diag.DiagnosticWithPath)
+		if withAttribute != c.attribute {
+			t.Errorf("Expected the diagnostic for %v to point at the attribute: %v", c.err, c.attribute)
+		}
+		var wrapped *APIError
+		if errors.As(c.err, &wrapped) && !strings.Contains(d.Detail(), "from gotify") {
+			t.Errorf("Expected the detail to contain Gotify's description, got %q", d.Detail())
+		}
+	}
+}
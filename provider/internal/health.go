@@ -0,0 +1,46 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+)
+
+// As reported by Gotify's unauthenticated "/health" endpoint.
+type Health struct {
+	Health   string `json:"health"`
+	Database string `json:"database"`
+}
+
+// Whether both the server and its database report healthy.
+func (h *Health) Healthy() bool {
+	return h.Health == "green" && h.Database == "green"
+}
+
+// Reads the health of the server, the generated API client does not know this endpoint.
+// Unhealthy servers respond with status 500 but still report their health, which is not an error here.
+func (c *AuthedGotifyClient) GetHealth(ctx context.Context) (*Health, error) {
+	var status Health
+	_, err := c.Client.Transport.Submit(&runtime.ClientOperation{
+		ID:                 "getHealth",
+		Method:             "GET",
+		PathPattern:        "/health",
+		ProducesMediaTypes: []string{runtime.JSONMime},
+		ConsumesMediaTypes: []string{runtime.JSONMime},
+		Schemes:            []string{"http", "https"},
+		Context:            ctx,
+		Params: runtime.ClientRequestWriterFunc(func(_ runtime.ClientRequest, _ strfmt.Registry) error {
+			return nil
+		}),
+		Reader: runtime.ClientResponseReaderFunc(func(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+			if response.Code() != http.StatusOK && response.Code() != http.StatusInternalServerError {
+				return nil, runtime.NewAPIError("getHealth", fmt.Sprintf("[GET /health] %s", response.Message()), response.Code())
+			}
+			return nil, consumer.Consume(response.Body(), &status)
+		}),
+	})
+	return &status, err
+}
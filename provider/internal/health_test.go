@@ -0,0 +1,56 @@
+package internal
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetHealthUnhealthy(t *testing.T) {
+	var requests atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintln(w, `{"health": "orange", "database": "red"}`)
+	}))
+	defer ts.Close()
+
+	// Like the provider defaults
+	gotify, err := NewAuthedClient(ts.URL, testCredentials, ClientOptions{MaxRetries: 3, RetryMaxWait: time.Second})
+	if err != nil {
+		t.Fatalf("Could not construct client: %v", err.Error())
+	}
+
+	status, err := gotify.GetHealth(t.Context())
+	if err != nil {
+		t.Fatalf("Expected an unhealthy server to still report its health: %v", err.Error())
+	}
+	if status.Health != "orange" || status.Database != "red" || status.Healthy() {
+		t.Errorf("Expected orange/red health, got %+v", status)
+	}
+	if requests.Load() != 1 {
+		t.Errorf("Expected the unhealthy response not to be retried, got %d requests", requests.Load())
+	}
+}
+
+func TestGetHealthUnreachable(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		fmt.Fprint(w, "upstream not ready")
+	}))
+	defer ts.Close()
+
+	gotify, err := NewAuthedClient(ts.URL, testCredentials, ClientOptions{})
+	if err != nil {
+		t.Fatalf("Could not construct client: %v", err.Error())
+	}
+
+	_, err = gotify.GetHealth(t.Context())
+	if err == nil {
+		t.Fatal("Expected an error when the proxy can't reach the server")
+	}
+}
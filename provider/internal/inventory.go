@@ -0,0 +1,109 @@
+package internal
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gotify/go-api-client/v2/client/client"
+	"github.com/gotify/go-api-client/v2/client/plugin"
+	"github.com/gotify/go-api-client/v2/models"
+)
+
+// How long a downloaded list is reused. Terraform starts a new provider for every command, so this only bounds how
+// stale a list can get during one long plan or apply.
+const inventoryTTL = 30 * time.Second
+
+// Snapshots of the application, client and plugin lists, shared by all resources and data sources.
+// Gotify has no endpoints to read a single one of them, so without this every resource downloads the full list.
+//
+// Every request that could change something on the server invalidates all snapshots, see inventoryTransport.
+type inventory struct {
+	// Bumped on every invalidation. A snapshot is only valid for the generation it was downloaded in.
+	generation atomic.Uint64
+	apps       snapshot[*Application]
+	clients    snapshot[*models.Client]
+	plugins    snapshot[*models.PluginConfExternal]
+}
+
+func (inv *inventory) invalidate() {
+	inv.generation.Add(1)
+}
+
+type snapshot[T any] struct {
+	// Held while downloading, so concurrent callers wait for the same download instead of starting their own.
+	mu         sync.Mutex
+	items      []T
+	generation uint64
+	fetched    time.Time
+}
+
+func (s *snapshot[T]) get(inv *inventory, fetch func() ([]T, error)) ([]T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Read before downloading: if something changes while the request is in flight, the result is already outdated.
+	generation := inv.generation.Load()
+	if !s.fetched.IsZero() && s.generation == generation && time.Since(s.fetched) < inventoryTTL {
+		return s.items, nil
+	}
+
+	items, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	s.items, s.generation, s.fetched = items, generation, time.Now()
+	return items, nil
+}
+
+// Invalidates the inventory after every request that is not read-only, whether it succeeded or not.
+type inventoryTransport struct {
+	inventory *inventory
+	next      http.RoundTripper
+}
+
+func (it *inventoryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		defer it.inventory.invalidate()
+	}
+	return it.next.RoundTrip(req)
+}
+
+func wrapWithInventory(inventory *inventory, wrap http.RoundTripper) http.RoundTripper {
+	return &inventoryTransport{inventory: inventory, next: wrap}
+}
+
+// Lists all applications. The list is shared, callers must not modify it or the applications in it.
+func (c *AuthedGotifyClient) GetApps(ctx context.Context) ([]*Application, error) {
+	return c.inventory.apps.get(c.inventory, func() ([]*Application, error) {
+		var apps []*Application
+		err := c.submitJSON(ctx, "getApps", "GET", "/application", nil, nil, &apps)
+		return apps, err
+	})
+}
+
+// Lists all clients. The list is shared, callers must not modify it or the clients in it.
+func (c *AuthedGotifyClient) GetClients(ctx context.Context) ([]*models.Client, error) {
+	return c.inventory.clients.get(c.inventory, func() ([]*models.Client, error) {
+		params := client.NewGetClientsParamsWithContext(ctx)
+		client_list, err := c.Client.Client.GetClients(params, c.Auth)
+		if err != nil {
+			return nil, err
+		}
+		return client_list.Payload, nil
+	})
+}
+
+// Lists all plugins. The list is shared, callers must not modify it or the plugins in it.
+func (c *AuthedGotifyClient) GetPlugins(ctx context.Context) ([]*models.PluginConfExternal, error) {
+	return c.inventory.plugins.get(c.inventory, func() ([]*models.PluginConfExternal, error) {
+		params := plugin.NewGetPluginsParamsWithContext(ctx)
+		plugin_list, err := c.Client.Plugin.GetPlugins(params, c.Auth)
+		if err != nil {
+			return nil, err
+		}
+		return plugin_list.Payload, nil
+	})
+}
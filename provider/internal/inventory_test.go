@@ -0,0 +1,112 @@
+package internal
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestInventoryShared(t *testing.T) {
+	var lists atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		lists.Add(1)
+		// Slow enough that all callers are waiting at the same time
+		time.Sleep(50 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, `[{"id": 1, "name": "test"}]`)
+	}))
+	defer ts.Close()
+
+	gotify, err := NewAuthedClient(ts.URL, testCredentials, ClientOptions{})
+	if err != nil {
+		t.Fatalf("Could not construct client: %v", err.Error())
+	}
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			apps, err := gotify.GetApps(t.Context())
+			if err != nil {
+				t.Errorf("Error during test request: %v", err.Error())
+			} else if len(apps) != 1 {
+				t.Errorf("Expected one application, got %d", len(apps))
+			}
+		}()
+	}
+	wg.Wait()
+
+	if lists.Load() != 1 {
+		t.Errorf("Expected the list to be downloaded once, got %d requests", lists.Load())
+	}
+}
+
+func TestInventoryInvalidated(t *testing.T) {
+	var lists atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if req.Method == http.MethodGet {
+			lists.Add(1)
+			fmt.Fprintln(w, "[]")
+		} else {
+			fmt.Fprintln(w, `{"id": 1, "name": "test"}`)
+		}
+	}))
+	defer ts.Close()
+
+	gotify, err := NewAuthedClient(ts.URL, testCredentials, ClientOptions{})
+	if err != nil {
+		t.Fatalf("Could not construct client: %v", err.Error())
+	}
+
+	if _, err := gotify.GetApps(t.Context()); err != nil {
+		t.Fatalf("Error during test request: %v", err.Error())
+	}
+	if _, err := gotify.GetApps(t.Context()); err != nil {
+		t.Fatalf("Error during test request: %v", err.Error())
+	}
+	if lists.Load() != 1 {
+		t.Fatalf("Expected the second list to be cached, got %d requests", lists.Load())
+	}
+
+	if _, err := gotify.CreateApp(t.Context(), &Application{}); err != nil {
+		t.Fatalf("Error during test request: %v", err.Error())
+	}
+	if _, err := gotify.GetApps(t.Context()); err != nil {
+		t.Fatalf("Error during test request: %v", err.Error())
+	}
+	if lists.Load() != 2 {
+		t.Errorf("Expected the list to be downloaded again after a change, got %d requests", lists.Load())
+	}
+}
+
+func TestInventoryErrorNotCached(t *testing.T) {
+	var lists atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if lists.Add(1) == 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprintln(w, `{"error": "Unauthorized", "errorCode": 401, "errorDescription": "you need to provide a valid access token or user credentials to access this api"}`)
+			return
+		}
+		fmt.Fprintln(w, "[]")
+	}))
+	defer ts.Close()
+
+	gotify, err := NewAuthedClient(ts.URL, testCredentials, ClientOptions{})
+	if err != nil {
+		t.Fatalf("Could not construct client: %v", err.Error())
+	}
+
+	if _, err := gotify.GetClients(t.Context()); err == nil {
+		t.Fatal("Expected the first request to fail")
+	}
+	if _, err := gotify.GetClients(t.Context()); err != nil {
+		t.Errorf("Expected the failed list not to be cached: %v", err.Error())
+	}
+}
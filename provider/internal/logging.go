@@ -0,0 +1,169 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// The tflog subsystem all requests to Gotify are logged to. Its level can be set separately with the
+// TF_LOG_PROVIDER_GOTIFY_API environment variable, otherwise it uses the level of the provider.
+const LogSubsystem = "api"
+
+// Bodies are only logged up to this size.
+const maxLoggedBody = 16 * 1024
+
+const redacted = "<redacted>"
+
+// Headers that carry credentials.
+var redactedHeaders = []string{"Authorization", "X-Gotify-Key"}
+
+// JSON fields that carry credentials, in request and response bodies.
+var redactedFields = map[string]bool{"token": true, "pass": true}
+
+// Logs every request sent to Gotify: method, URL, status, duration and response size at DEBUG level,
+// headers and JSON bodies at TRACE level. Credentials are redacted.
+type LoggingTransport struct {
+	Next http.RoundTripper
+}
+
+func (lt *LoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Include the fields of the provider, like the resource type the request is sent for
+	ctx := tflog.NewSubsystem(req.Context(), LogSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_GOTIFY", "API"), tflog.WithRootFields())
+	fields := map[string]interface{}{
+		"method": req.Method,
+		"url":    redactURL(req.URL),
+	}
+
+	// Read the body to log it, and send a copy
+	var reqBody []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+	tflog.SubsystemTrace(ctx, LogSubsystem, "Sending request to Gotify", merge(fields, map[string]interface{}{
+		"headers": redactHeaders(req.Header),
+		"body":    redactBody(req.Header.Get("Content-Type"), reqBody),
+	}))
+
+	start := time.Now()
+	resp, err := lt.Next.RoundTrip(req)
+	if err != nil {
+		tflog.SubsystemDebug(ctx, LogSubsystem, "Request to Gotify failed", merge(fields, map[string]interface{}{
+			"duration_ms": time.Since(start).Milliseconds(),
+			"error":       err.Error(),
+		}))
+		return nil, err
+	}
+
+	// Responses are small JSON documents, read them to know their size and log them
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	if err != nil {
+		return nil, err
+	}
+
+	fields = merge(fields, map[string]interface{}{
+		"status":         resp.StatusCode,
+		"duration_ms":    time.Since(start).Milliseconds(),
+		"response_bytes": len(respBody),
+	})
+	tflog.SubsystemDebug(ctx, LogSubsystem, "Received response from Gotify", fields)
+	tflog.SubsystemTrace(ctx, LogSubsystem, "Received response body from Gotify", merge(fields, map[string]interface{}{
+		"headers": redactHeaders(resp.Header),
+		"body":    redactBody(resp.Header.Get("Content-Type"), respBody),
+	}))
+	return resp, nil
+}
+
+func wrapWithLogging(wrap http.RoundTripper) http.RoundTripper {
+	return &LoggingTransport{Next: wrap}
+}
+
+func merge(fields map[string]interface{}, more map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(fields)+len(more))
+	for key, value := range fields {
+		merged[key] = value
+	}
+	for key, value := range more {
+		merged[key] = value
+	}
+	return merged
+}
+
+// Gotify also accepts tokens as a query parameter.
+func redactURL(u *url.URL) string {
+	query := u.Query()
+	if !query.Has("token") {
+		return u.String()
+	}
+	query.Set("token", redacted)
+	redactedURL := *u
+	redactedURL.RawQuery = query.Encode()
+	return redactedURL.String()
+}
+
+func redactHeaders(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+	for name := range header {
+		headers[name] = header.Get(name)
+	}
+	for _, name := range redactedHeaders {
+		if header.Get(name) != "" {
+			headers[http.CanonicalHeaderKey(name)] = redacted
+		}
+	}
+	return headers
+}
+
+func redactBody(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	// Only JSON can be redacted field by field. Everything else, like plugin configs in YAML which may contain webhook
+	// URLs, or application images, is only logged by its size.
+	var document interface{}
+	if !strings.Contains(contentType, "json") || json.Unmarshal(body, &document) != nil {
+		return fmt.Sprintf("<%d bytes>", len(body))
+	}
+	encoded, err := json.Marshal(redactFields(document))
+	if err != nil {
+		return fmt.Sprintf("<%d bytes>", len(body))
+	}
+	if len(encoded) > maxLoggedBody {
+		return string(encoded[:maxLoggedBody]) + "..."
+	}
+	return string(encoded)
+}
+
+func redactFields(document interface{}) interface{} {
+	switch value := document.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			if redactedFields[key] {
+				value[key] = redacted
+			} else {
+				value[key] = redactFields(field)
+			}
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = redactFields(item)
+		}
+	}
+	return document
+}
@@ -0,0 +1,110 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestLoggingRedactsCredentials(t *testing.T) {
+	t.Setenv("TF_LOG_PROVIDER_GOTIFY_API", "TRACE")
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/application":
+			fmt.Fprintln(w, `{"id": 3, "name": "test", "token": "app-secret"}`)
+		case "/message":
+			fmt.Fprintln(w, `{"id": 7, "appid": 3, "message": "Hello"}`)
+		default:
+			fmt.Fprintln(w, `{}`)
+		}
+	}))
+	defer ts.Close()
+
+	gotify, err := NewAuthedClient(ts.URL, Credentials{Username: "test", Password: "user-secret"}, ClientOptions{})
+	if err != nil {
+		t.Fatalf("Could not construct client: %v", err.Error())
+	}
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(t.Context(), &output)
+	if _, err := gotify.CreateApp(ctx, &Application{}); err != nil {
+		t.Fatalf("Error during test request: %v", err.Error())
+	}
+	if _, err := gotify.CreateMessage(ctx, "message-secret", &Message{Message: "Hello"}); err != nil {
+		t.Fatalf("Error during test request: %v", err.Error())
+	}
+	if err := gotify.UpdateCurrentUserPassword(ctx, "new-secret"); err != nil {
+		t.Fatalf("Error during test request: %v", err.Error())
+	}
+
+	for _, secret := range []string{"user-secret", "dGVzdDp1c2VyLXNlY3JldA==", "app-secret", "message-secret", "new-secret"} {
+		if strings.Contains(output.String(), secret) {
+			t.Errorf("Expected %q to be redacted from the log:\n%s", secret, output.String())
+		}
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("Could not decode log: %v", err.Error())
+	}
+	var responses int
+	for _, entry := range entries {
+		if entry["@module"] != "provider."+LogSubsystem {
+			t.Errorf("Expected all entries in the %q subsystem, got %v", LogSubsystem, entry["@module"])
+		}
+		if entry["@message"] != "Received response from Gotify" {
+			continue
+		}
+		responses++
+		if entry["@level"] != "debug" || entry["status"] != float64(200) || entry["response_bytes"] == nil || entry["duration_ms"] == nil {
+			t.Errorf("Unexpected response entry: %v", entry)
+		}
+	}
+	if responses != 3 {
+		t.Errorf("Expected 3 logged responses, got %d", responses)
+	}
+}
+
+func TestLoggingOmitsYAMLBodies(t *testing.T) {
+	t.Setenv("TF_LOG_PROVIDER_GOTIFY_API", "TRACE")
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		// Plugin configs are YAML, like the server reports them
+		w.Header().Set("Content-Type", "application/x-yaml")
+		fmt.Fprintln(w, "webhook: https://hooks.example.com/response-secret")
+	}))
+	defer ts.Close()
+
+	gotify, err := NewAuthedClient(ts.URL, testCredentials, ClientOptions{})
+	if err != nil {
+		t.Fatalf("Could not construct client: %v", err.Error())
+	}
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(t.Context(), &output)
+	config := map[string]interface{}{"webhook": "https://hooks.example.com/request-secret"}
+	if err := gotify.UpdatePluginConfig(ctx, 1, config); err != nil {
+		t.Fatalf("Error during test request: %v", err.Error())
+	}
+
+	for _, secret := range []string{"request-secret", "response-secret"} {
+		if strings.Contains(output.String(), secret) {
+			t.Errorf("Expected %q to be omitted from the log:\n%s", secret, output.String())
+		}
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("Could not decode log: %v", err.Error())
+	}
+	for _, entry := range entries {
+		if body, ok := entry["body"]; ok && body != "<50 bytes>" && body != "<51 bytes>" {
+			t.Errorf("Expected only the size of the YAML bodies to be logged, got %v", body)
+		}
+	}
+}
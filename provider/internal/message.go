@@ -0,0 +1,25 @@
+package internal
+
+import (
+	"context"
+
+	"github.com/go-openapi/runtime"
+	"github.com/gotify/go-api-client/v2/auth"
+	"github.com/gotify/go-api-client/v2/models"
+)
+
+// Message to publish. Unlike models.MessageExternal, the priority is only sent when set, so that the server falls back
+// to the applications default priority otherwise.
+type Message struct {
+	Title    string                 `json:"title,omitempty"`
+	Message  string                 `json:"message"`
+	Priority *int64                 `json:"priority,omitempty"`
+	Extras   map[string]interface{} `json:"extras,omitempty"`
+}
+
+// Publishes the message as the application the token belongs to.
+func (c *AuthedGotifyClient) CreateMessage(ctx context.Context, appToken string, message *Message) (*models.MessageExternal, error) {
+	var created models.MessageExternal
+	err := c.submit(ctx, auth.TokenAuth(appToken), "createMessage", "POST", "/message", runtime.JSONMime, nil, message, &created)
+	return &created, err
+}
@@ -0,0 +1,45 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateMessage(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("X-Gotify-Key") != "app-token" {
+			t.Errorf("Expected the application token in \"X-Gotify-Key\", got %q", req.Header.Get("X-Gotify-Key"))
+		}
+		if _, _, ok := req.BasicAuth(); ok {
+			t.Error("Expected no basic auth when publishing as an application")
+		}
+
+		var body map[string]interface{}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Fatalf("Could not decode request body: %v", err.Error())
+		}
+		if _, ok := body["priority"]; ok {
+			t.Errorf("Expected no priority to be sent, got %v", body["priority"])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, `{"id": 7, "appid": 3, "message": "Hello", "priority": 4, "date": "2024-01-02T03:04:05Z"}`)
+	}))
+	defer ts.Close()
+
+	gotify, err := NewAuthedClient(ts.URL, testCredentials, ClientOptions{})
+	if err != nil {
+		t.Fatalf("Could not construct client: %v", err.Error())
+	}
+
+	created, err := gotify.CreateMessage(t.Context(), "app-token", &Message{Message: "Hello"})
+	if err != nil {
+		t.Fatalf("Error during test request: %v", err.Error())
+	}
+	if created.ID != 7 || created.ApplicationID != 3 || created.Priority != 4 {
+		t.Errorf("Unexpected message returned: %+v", created)
+	}
+}
@@ -0,0 +1,15 @@
+package internal
+
+import (
+	"context"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/swag"
+)
+
+// Uploads a new configuration for the given plugin, encoded as YAML.
+// The generated API client does not send a request body for this endpoint, so the request is built by hand.
+func (c *AuthedGotifyClient) UpdatePluginConfig(ctx context.Context, id int64, config interface{}) error {
+	params := map[string]string{"id": swag.FormatInt64(id)}
+	return c.submit(ctx, c.Auth, "updatePluginConfig", "POST", "/plugin/{id}/config", runtime.YAMLMime, params, config, nil)
+}
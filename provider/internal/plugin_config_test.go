@@ -0,0 +1,35 @@
+package internal
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUpdatePluginConfig(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/plugin/3/config" {
+			t.Errorf("Expected path %q, got %q", "/plugin/3/config", req.URL.Path)
+		}
+		if contentType := req.Header.Get("Content-Type"); contentType != "application/x-yaml" {
+			t.Errorf("Expected YAML content type, got %q", contentType)
+		}
+		body, _ := io.ReadAll(req.Body)
+		if string(body) != "channel: alerts\n" {
+			t.Errorf("Expected YAML body, got %q", string(body))
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	gotify, err := NewAuthedClient(ts.URL, testCredentials, ClientOptions{})
+	if err != nil {
+		t.Fatalf("Could not construct client: %v", err.Error())
+	}
+
+	err = gotify.UpdatePluginConfig(t.Context(), 3, map[string]interface{}{"channel": "alerts"})
+	if err != nil {
+		t.Fatalf("Error during test request: %v", err.Error())
+	}
+}
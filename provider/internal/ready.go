@@ -0,0 +1,142 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// How long to wait for the server to become ready, and how often to ask it.
+type WaitForReady struct {
+	Timeout      time.Duration
+	PollInterval time.Duration
+}
+
+// Holds back all requests until the servers health endpoint reported healthy once.
+//
+// This happens on the first request rather than when the provider is configured, so that planning doesn't wait for a
+// server which is only deployed during the apply.
+type ReadyTransport struct {
+	HealthURL string
+	Wait      WaitForReady
+	Next      http.RoundTripper
+
+	mu    sync.Mutex
+	ready bool
+	// The wait in progress, nil if there is none
+	wait *readyWait
+}
+
+// Shared by all requests that arrive while the server is not ready yet.
+type readyWait struct {
+	// Closed once the wait is over, err is set before
+	done chan struct{}
+	err  error
+}
+
+func (rt *ReadyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := rt.awaitReady(req.Context()); err != nil {
+		return nil, err
+	}
+	return rt.Next.RoundTrip(req)
+}
+
+// Whether the server already reported healthy, without waiting for it.
+func (rt *ReadyTransport) Ready() bool {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	return rt.ready
+}
+
+// Concurrent requests share a single wait, but each stops waiting as soon as its own context is done. Only success is
+// remembered: if the wait times out, the next request starts a new one.
+func (rt *ReadyTransport) awaitReady(ctx context.Context) error {
+	rt.mu.Lock()
+	if rt.ready {
+		rt.mu.Unlock()
+		return nil
+	}
+	wait := rt.wait
+	if wait == nil {
+		wait = &readyWait{done: make(chan struct{})}
+		rt.wait = wait
+		// Requests that give up must not end the wait for the others
+		go rt.runWait(context.WithoutCancel(ctx), wait)
+	}
+	rt.mu.Unlock()
+
+	select {
+	case <-wait.done:
+		return wait.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (rt *ReadyTransport) runWait(ctx context.Context, wait *readyWait) {
+	wait.err = rt.waitForReady(ctx)
+
+	rt.mu.Lock()
+	rt.ready = wait.err == nil
+	rt.wait = nil
+	rt.mu.Unlock()
+	close(wait.done)
+}
+
+func (rt *ReadyTransport) waitForReady(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, rt.Wait.Timeout)
+	defer cancel()
+
+	last := "none"
+	for {
+		healthy, response := rt.checkHealth(ctx)
+		if healthy {
+			return nil
+		}
+		if ctx.Err() == nil {
+			// Don't overwrite the last real response with the error from hitting the timeout
+			last = response
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("gotify server at %s did not report healthy within %s, last health response: %s", rt.HealthURL, rt.Wait.Timeout, last)
+		case <-time.After(rt.Wait.PollInterval):
+		}
+	}
+}
+
+// Whether the server is healthy, along with a description of its response.
+func (rt *ReadyTransport) checkHealth(ctx context.Context) (bool, string) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rt.HealthURL, nil)
+	if err != nil {
+		return false, err.Error()
+	}
+	resp, err := rt.Next.RoundTrip(req)
+	if err != nil {
+		return false, err.Error()
+	}
+	defer resp.Body.Close()
+
+	// Proxies might answer with entire HTML pages, only keep the start
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 512))
+	if err != nil {
+		return false, err.Error()
+	}
+	response := strings.TrimSpace(fmt.Sprintf("%s %s", resp.Status, body))
+
+	var status Health
+	if resp.StatusCode != http.StatusOK || json.Unmarshal(body, &status) != nil {
+		return false, response
+	}
+	return status.Healthy(), response
+}
+
+func wrapWithReady(healthURL string, wait WaitForReady, wrap http.RoundTripper) *ReadyTransport {
+	return &ReadyTransport{HealthURL: healthURL, Wait: wait, Next: wrap}
+}
@@ -0,0 +1,146 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gotify/go-api-client/v2/client/application"
+)
+
+func TestWaitForReady(t *testing.T) {
+	var checks atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if req.URL.Path == "/health" {
+			if checks.Add(1) < 3 {
+				w.WriteHeader(http.StatusInternalServerError)
+				fmt.Fprintln(w, `{"health": "orange", "database": "red"}`)
+				return
+			}
+			fmt.Fprintln(w, `{"health": "green", "database": "green"}`)
+			return
+		}
+		if checks.Load() < 3 {
+			t.Errorf("Expected no request to %q before the server is healthy", req.URL.Path)
+		}
+		fmt.Fprintln(w, "[]")
+	}))
+	defer ts.Close()
+
+	wait := &WaitForReady{Timeout: time.Second, PollInterval: 10 * time.Millisecond}
+	gotify, err := NewAuthedClient(ts.URL, testCredentials, ClientOptions{WaitForReady: wait})
+	if err != nil {
+		t.Fatalf("Could not construct client: %v", err.Error())
+	}
+
+	for i := 0; i < 2; i++ {
+		params := application.NewGetAppsParams()
+		if _, err := gotify.Client.Application.GetApps(params, gotify.Auth); err != nil {
+			t.Fatalf("Error during test request: %v", err.Error())
+		}
+	}
+	if checks.Load() != 3 {
+		t.Errorf("Expected to only wait before the first request, got %d health checks", checks.Load())
+	}
+}
+
+func TestWaitForReadyTimeout(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/health" {
+			t.Errorf("Expected no request to %q while the server is unhealthy", req.URL.Path)
+		}
+		w.WriteHeader(http.StatusBadGateway)
+		fmt.Fprint(w, "upstream not ready")
+	}))
+	defer ts.Close()
+
+	wait := &WaitForReady{Timeout: 100 * time.Millisecond, PollInterval: 10 * time.Millisecond}
+	gotify, err := NewAuthedClient(ts.URL, testCredentials, ClientOptions{WaitForReady: wait})
+	if err != nil {
+		t.Fatalf("Could not construct client: %v", err.Error())
+	}
+
+	params := application.NewGetAppsParams()
+	_, err = gotify.Client.Application.GetApps(params, gotify.Auth)
+	if err == nil {
+		t.Fatal("Expected an error for a server that never gets healthy, got none")
+	}
+	if !strings.Contains(err.Error(), "502 Bad Gateway upstream not ready") {
+		t.Errorf("Expected the error to contain the last health response, got %q", err.Error())
+	}
+}
+
+func TestWaitForReadyCanceled(t *testing.T) {
+	var healthy atomic.Bool
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if req.URL.Path == "/health" {
+			if !healthy.Load() {
+				w.WriteHeader(http.StatusInternalServerError)
+				fmt.Fprintln(w, `{"health": "orange", "database": "red"}`)
+				return
+			}
+			fmt.Fprintln(w, `{"health": "green", "database": "green"}`)
+			return
+		}
+		fmt.Fprintln(w, "[]")
+	}))
+	defer ts.Close()
+
+	wait := &WaitForReady{Timeout: time.Second, PollInterval: 10 * time.Millisecond}
+	gotify, err := NewAuthedClient(ts.URL, testCredentials, ClientOptions{WaitForReady: wait})
+	if err != nil {
+		t.Fatalf("Could not construct client: %v", err.Error())
+	}
+
+	// The first request gives up while the server is still starting
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := gotify.GetApps(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the first request to time out, got %v", err)
+	}
+
+	// Later requests wait again instead of failing with the same error
+	healthy.Store(true)
+	if _, err := gotify.GetClients(t.Context()); err != nil {
+		t.Fatalf("Expected the request to succeed once the server is healthy, got %v", err.Error())
+	}
+}
+
+func TestWaitForReadyConcurrentCanceled(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		fmt.Fprint(w, "upstream not ready")
+	}))
+	defer ts.Close()
+
+	wait := &WaitForReady{Timeout: 5 * time.Second, PollInterval: 10 * time.Millisecond}
+	gotify, err := NewAuthedClient(ts.URL, testCredentials, ClientOptions{WaitForReady: wait})
+	if err != nil {
+		t.Fatalf("Could not construct client: %v", err.Error())
+	}
+
+	// The first request starts waiting for the server
+	first, cancelFirst := context.WithTimeout(t.Context(), 2*time.Second)
+	defer cancelFirst()
+	go func() { _, _ = gotify.GetApps(first) }()
+	time.Sleep(50 * time.Millisecond)
+
+	// Like a resource with a short timeout, it must not wait for the first request to give up
+	ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := gotify.GetClients(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the request to time out, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the request to stop waiting at its own timeout, took %s", elapsed)
+	}
+}
@@ -0,0 +1,120 @@
+package internal
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The first retry waits about this long, every further retry doubles it up to RetryTransport.MaxWait.
+const retryBaseWait = 500 * time.Millisecond
+
+// Retries requests that failed for transient reasons, like Gotify still starting up, with exponential backoff and jitter.
+//
+// Idempotent requests are retried on connection errors, 429 and 5xx responses. Non-idempotent requests (like creating
+// an application) are only retried when they certainly never reached Gotify: the connection could not be established
+// or the server answered with 429.
+type RetryTransport struct {
+	MaxRetries int
+	MaxWait    time.Duration
+	Next       http.RoundTripper
+}
+
+func (rt *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Buffer the body, so it can be sent again for every attempt
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		try := req.Clone(req.Context())
+		if body != nil {
+			try.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		resp, err := rt.Next.RoundTrip(try)
+		if attempt >= rt.MaxRetries || !shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		wait := rt.backoff(attempt, resp)
+		if resp != nil {
+			// Allow the connection to be reused
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		// Cancelled or timed out, the user wants us to stop
+		return false
+	}
+	if err != nil {
+		// A failed dial means the request was never sent, which is always safe to retry
+		var opErr *net.OpError
+		if errors.As(err, &opErr) && opErr.Op == "dial" {
+			return true
+		}
+		return isIdempotent(req.Method)
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		// The server refused to handle the request at all
+		return true
+	}
+	if resp.StatusCode == http.StatusInternalServerError && strings.HasSuffix(req.URL.Path, "/health") {
+		// Unhealthy servers report their health with this status, asking again does not make them healthy
+		return false
+	}
+	return resp.StatusCode >= 500 && isIdempotent(req.Method)
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// Exponential backoff with jitter, unless the server asked for a specific wait via the Retry-After header.
+func (rt *RetryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			return min(time.Duration(seconds)*time.Second, rt.MaxWait)
+		}
+	}
+
+	// Stop doubling at the maximum, shifting by the attempt would overflow after enough retries
+	wait := retryBaseWait
+	for i := 0; i < attempt && wait < rt.MaxWait; i++ {
+		wait *= 2
+	}
+	wait = min(wait, rt.MaxWait)
+	// Spread retries between half and the full wait time
+	return wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+}
+
+func wrapWithRetry(maxRetries int, maxWait time.Duration, wrap http.RoundTripper) http.RoundTripper {
+	return &RetryTransport{MaxRetries: maxRetries, MaxWait: maxWait, Next: wrap}
+}
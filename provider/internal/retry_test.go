@@ -0,0 +1,165 @@
+package internal
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gotify/go-api-client/v2/client/application"
+	"github.com/gotify/go-api-client/v2/models"
+)
+
+var testRetryOptions = ClientOptions{MaxRetries: 3, RetryMaxWait: 10 * time.Millisecond}
+
+func TestRetryIdempotentOnServerError(t *testing.T) {
+	var attempts atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, "[]")
+	}))
+	defer ts.Close()
+
+	gotify, err := NewAuthedClient(ts.URL, testCredentials, testRetryOptions)
+	if err != nil {
+		t.Fatalf("Could not construct client: %v", err.Error())
+	}
+
+	params := application.NewGetAppsParams()
+	if _, err := gotify.Client.Application.GetApps(params, gotify.Auth); err != nil {
+		t.Fatalf("Error during test request: %v", err.Error())
+	}
+	if attempts.Load() != 3 {
+		t.Errorf("Expected 3 attempts, got %d", attempts.Load())
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	var attempts atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	gotify, err := NewAuthedClient(ts.URL, testCredentials, testRetryOptions)
+	if err != nil {
+		t.Fatalf("Could not construct client: %v", err.Error())
+	}
+
+	params := application.NewGetAppsParams()
+	if _, err := gotify.Client.Application.GetApps(params, gotify.Auth); err == nil {
+		t.Fatal("Expected an error for a 503 response, got none")
+	}
+	if attempts.Load() != 4 {
+		t.Errorf("Expected 1 attempt and 3 retries, got %d attempts", attempts.Load())
+	}
+}
+
+func TestRetryNonIdempotentNotReplayed(t *testing.T) {
+	var attempts atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer ts.Close()
+
+	gotify, err := NewAuthedClient(ts.URL, testCredentials, testRetryOptions)
+	if err != nil {
+		t.Fatalf("Could not construct client: %v", err.Error())
+	}
+
+	params := application.NewCreateAppParams()
+	params.Body = &models.Application{Name: "test"}
+	if _, err := gotify.Client.Application.CreateApp(params, gotify.Auth); err == nil {
+		t.Fatal("Expected an error for a 502 response, got none")
+	}
+	if attempts.Load() != 1 {
+		t.Errorf("Expected a POST to never be replayed after a 502, got %d attempts", attempts.Load())
+	}
+}
+
+func TestRetryNonIdempotentOnTooManyRequests(t *testing.T) {
+	var attempts atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		// The body must be sent again with every attempt
+		body, _ := io.ReadAll(req.Body)
+		if len(body) == 0 {
+			t.Errorf("Expected a request body on attempt %d, got none", attempts.Load()+1)
+		}
+		if attempts.Add(1) < 2 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, `{"id": 1, "name": "test", "token": "abc"}`)
+	}))
+	defer ts.Close()
+
+	gotify, err := NewAuthedClient(ts.URL, testCredentials, testRetryOptions)
+	if err != nil {
+		t.Fatalf("Could not construct client: %v", err.Error())
+	}
+
+	params := application.NewCreateAppParams()
+	params.Body = &models.Application{Name: "test"}
+	if _, err := gotify.Client.Application.CreateApp(params, gotify.Auth); err != nil {
+		t.Fatalf("Error during test request: %v", err.Error())
+	}
+	if attempts.Load() != 2 {
+		t.Errorf("Expected 2 attempts, got %d", attempts.Load())
+	}
+}
+
+func TestRetryConnectionRefused(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {}))
+	// Nothing listens on this address anymore
+	ts.Close()
+
+	var attempts atomic.Int32
+	transport := &RetryTransport{
+		MaxRetries: 2,
+		MaxWait:    10 * time.Millisecond,
+		Next: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			attempts.Add(1)
+			return http.DefaultTransport.RoundTrip(req)
+		}),
+	}
+
+	req, _ := http.NewRequest(http.MethodPost, ts.URL+"/application", nil)
+	if _, err := transport.RoundTrip(req); err == nil {
+		t.Fatal("Expected an error for a closed server, got none")
+	}
+	if attempts.Load() != 3 {
+		t.Errorf("Expected a refused connection to be retried, got %d attempts", attempts.Load())
+	}
+}
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRetryBackoffCapped(t *testing.T) {
+	rt := &RetryTransport{MaxRetries: 100, MaxWait: 30 * time.Second}
+
+	// Shifting by the attempt alone would overflow long before the last retry
+	for _, attempt := range []int{0, 1, 34, 35, 63, 64, 100} {
+		wait := rt.backoff(attempt, nil)
+		if wait < 0 || wait > rt.MaxWait {
+			t.Errorf("Expected a wait between 0 and %s for attempt %d, got %s", rt.MaxWait, attempt, wait)
+		}
+	}
+	if wait := rt.backoff(100, nil); wait < rt.MaxWait/2 {
+		t.Errorf("Expected late attempts to wait at least half of %s, got %s", rt.MaxWait, wait)
+	}
+}
@@ -0,0 +1,62 @@
+package internal
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+)
+
+// Limits how long a request may take, including all of its retries.
+//
+// Waiting for the server to become ready is not limited by this, it has its own timeout.
+type TimeoutTransport struct {
+	Timeout time.Duration
+	Next    http.RoundTripper
+}
+
+func (tt *TimeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), tt.Timeout)
+	resp, err := tt.Next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	// The body is read after this returns, so the request may only be canceled once it is closed
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
+
+func wrapWithTimeout(timeout time.Duration, wrap http.RoundTripper) http.RoundTripper {
+	return &TimeoutTransport{Timeout: timeout, Next: wrap}
+}
+
+// The generated API client gives every request a fixed 30 second timeout, which would also cut short waiting for the
+// server to become ready. This removes it, so only the context of the request and TimeoutTransport limit requests.
+type contextTransport struct {
+	next runtime.ClientTransport
+}
+
+func (ct *contextTransport) Submit(operation *runtime.ClientOperation) (interface{}, error) {
+	params := operation.Params
+	operation.Params = runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest, reg strfmt.Registry) error {
+		if err := params.WriteToRequest(r, reg); err != nil {
+			return err
+		}
+		return r.SetTimeout(0)
+	})
+	return ct.next.Submit(operation)
+}
@@ -0,0 +1,78 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func slowServer(t *testing.T, latency time.Duration) *httptest.Server {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		select {
+		case <-time.After(latency):
+		case <-req.Context().Done():
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, "[]")
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestRequestTimeout(t *testing.T) {
+	ts := slowServer(t, 2*time.Second)
+
+	gotify, err := NewAuthedClient(ts.URL, testCredentials, ClientOptions{RequestTimeout: 100 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("Could not construct client: %v", err.Error())
+	}
+
+	start := time.Now()
+	_, err = gotify.GetApps(t.Context())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the request to time out, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the request to be canceled after the timeout, took %s", elapsed)
+	}
+}
+
+func TestRequestTimeoutReadsBody(t *testing.T) {
+	ts := slowServer(t, 0)
+
+	gotify, err := NewAuthedClient(ts.URL, testCredentials, ClientOptions{RequestTimeout: time.Second})
+	if err != nil {
+		t.Fatalf("Could not construct client: %v", err.Error())
+	}
+
+	// The timeout must not cancel the request before the response body was read
+	if _, err := gotify.GetClients(t.Context()); err != nil {
+		t.Fatalf("Error during test request: %v", err.Error())
+	}
+}
+
+func TestRequestCanceled(t *testing.T) {
+	ts := slowServer(t, 2*time.Second)
+
+	gotify, err := NewAuthedClient(ts.URL, testCredentials, ClientOptions{})
+	if err != nil {
+		t.Fatalf("Could not construct client: %v", err.Error())
+	}
+
+	// Like Terraform does when interrupted
+	ctx, cancel := context.WithCancel(t.Context())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err = gotify.GetPlugins(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected the request to be canceled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the request to stop when canceled, took %s", elapsed)
+	}
+}
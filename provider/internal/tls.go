@@ -0,0 +1,60 @@
+package internal
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// TLS settings for the connection to the Gotify server. All certificates and keys are PEM encoded.
+type TLSConfig struct {
+	// Trusted in addition to the system certificate pool.
+	CACertificate []byte
+	// Presented to the server for mutual TLS. Both must be set or neither.
+	ClientCertificate  []byte
+	ClientKey          []byte
+	InsecureSkipVerify bool
+	// Overrides the name used to verify the server certificate, which defaults to the endpoint host.
+	ServerName string
+}
+
+func (c TLSConfig) build() (*tls.Config, error) {
+	config := &tls.Config{
+		InsecureSkipVerify: c.InsecureSkipVerify,
+		ServerName:         c.ServerName,
+	}
+
+	if len(c.CACertificate) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(c.CACertificate) {
+			return nil, fmt.Errorf("CA certificate does not contain any valid PEM encoded certificates")
+		}
+		config.RootCAs = pool
+	}
+
+	if len(c.ClientCertificate) > 0 || len(c.ClientKey) > 0 {
+		cert, err := tls.X509KeyPair(c.ClientCertificate, c.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("could not load client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
+
+// Returns the value itself if it is PEM content, otherwise reads the PEM content from the file at that path.
+func LoadPEM(value string) ([]byte, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+	return os.ReadFile(value)
+}
@@ -0,0 +1,144 @@
+package internal
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gotify/go-api-client/v2/client/application"
+)
+
+func newTLSTestServer(t *testing.T, clientAuth tls.ClientAuthType) *httptest.Server {
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if clientAuth != tls.NoClientCert && len(req.TLS.PeerCertificates) == 0 {
+			t.Error("Expected a client certificate, got none")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, "[]")
+	}))
+	ts.TLS = &tls.Config{ClientAuth: clientAuth}
+	ts.StartTLS()
+	return ts
+}
+
+func serverCAPEM(ts *httptest.Server) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+}
+
+func getApps(t *testing.T, url string, options ClientOptions) error {
+	gotify, err := NewAuthedClient(url, testCredentials, options)
+	if err != nil {
+		t.Fatalf("Could not construct client: %v", err.Error())
+	}
+	_, err = gotify.Client.Application.GetApps(application.NewGetAppsParams(), gotify.Auth)
+	return err
+}
+
+func TestTLSCustomCA(t *testing.T) {
+	ts := newTLSTestServer(t, tls.NoClientCert)
+	defer ts.Close()
+
+	if err := getApps(t, ts.URL, ClientOptions{}); err == nil {
+		t.Error("Expected request to fail without trusting the CA")
+	}
+	if err := getApps(t, ts.URL, ClientOptions{TLS: TLSConfig{CACertificate: serverCAPEM(ts)}}); err != nil {
+		t.Errorf("Expected request to succeed with custom CA, got: %v", err.Error())
+	}
+}
+
+func TestTLSInsecureSkipVerify(t *testing.T) {
+	ts := newTLSTestServer(t, tls.NoClientCert)
+	defer ts.Close()
+
+	if err := getApps(t, ts.URL, ClientOptions{TLS: TLSConfig{InsecureSkipVerify: true}}); err != nil {
+		t.Errorf("Expected request to succeed when skipping verification, got: %v", err.Error())
+	}
+}
+
+func TestTLSServerNameWithHostHeader(t *testing.T) {
+	ts := newTLSTestServer(t, tls.NoClientCert)
+	defer ts.Close()
+
+	// The httptest certificate is valid for "example.com"
+	host := "example.com"
+	options := ClientOptions{HostHeader: &host, TLS: TLSConfig{CACertificate: serverCAPEM(ts), ServerName: "example.com"}}
+	if err := getApps(t, ts.URL, options); err != nil {
+		t.Errorf("Expected request to succeed with matching server name, got: %v", err.Error())
+	}
+
+	options.TLS.ServerName = "other.local"
+	if err := getApps(t, ts.URL, options); err == nil {
+		t.Error("Expected request to fail with a server name not in the certificate")
+	}
+}
+
+func TestTLSClientCertificate(t *testing.T) {
+	ts := newTLSTestServer(t, tls.RequireAnyClientCert)
+	defer ts.Close()
+
+	cert, key := generateClientCertificate(t)
+	options := ClientOptions{TLS: TLSConfig{CACertificate: serverCAPEM(ts), ClientCertificate: cert, ClientKey: key}}
+	if err := getApps(t, ts.URL, options); err != nil {
+		t.Errorf("Expected request to succeed with client certificate, got: %v", err.Error())
+	}
+}
+
+func TestTLSInvalidClientCertificate(t *testing.T) {
+	_, err := NewAuthedClient("https://localhost", testCredentials, ClientOptions{TLS: TLSConfig{ClientCertificate: []byte("nope")}})
+	if err == nil {
+		t.Error("Expected an error for an invalid client certificate, got none")
+	}
+}
+
+func TestLoadPEM(t *testing.T) {
+	content := "-----BEGIN CERTIFICATE-----\nabc\n-----END CERTIFICATE-----\n"
+
+	inline, err := LoadPEM(content)
+	if err != nil || string(inline) != content {
+		t.Errorf("Expected inline PEM to be returned as-is, got %q (%v)", inline, err)
+	}
+
+	file := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatalf("Could not write test file: %v", err.Error())
+	}
+	fromFile, err := LoadPEM(file)
+	if err != nil || string(fromFile) != content {
+		t.Errorf("Expected PEM to be read from file, got %q (%v)", fromFile, err)
+	}
+}
+
+func generateClientCertificate(t *testing.T) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Could not generate key: %v", err.Error())
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Could not create certificate: %v", err.Error())
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Could not marshal key: %v", err.Error())
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+}
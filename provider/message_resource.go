@@ -0,0 +1,340 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"terraform-provider-gotify/provider/internal"
+	"time"
+
+	"github.com/gotify/go-api-client/v2/client/message"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	// Ensure implementation satisfies expected interfaces - compilition fails here otherwise.
+	_ resource.Resource                   = &MessageResource{}
+	_ resource.ResourceWithConfigure      = &MessageResource{}
+	_ resource.ResourceWithValidateConfig = &MessageResource{}
+)
+
+type MessageResource struct {
+	gotify *internal.AuthedGotifyClient
+}
+
+func NewMessageResource() resource.Resource {
+	return &MessageResource{}
+}
+
+type MessageResourceModel struct {
+	ApplicationId types.Int64    `tfsdk:"application_id"`
+	Token         types.String   `tfsdk:"token"`
+	Title         types.String   `tfsdk:"title"`
+	Message       types.String   `tfsdk:"message"`
+	Priority      types.Int64    `tfsdk:"priority"`
+	Extras        types.String   `tfsdk:"extras"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+	// Read-only after apply
+	Id   types.Int64  `tfsdk:"id"`
+	Date types.String `tfsdk:"date"`
+}
+
+func (r *MessageResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_message"
+}
+
+func (r *MessageResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "A message published to Gotify by an application, for example to announce infrastructure changes.",
+		MarkdownDescription: "Messages can not be changed once they are sent. Changing any argument sends a new message and deletes the old one.\n\nThe application must belong to the user the provider is configured with, so that the message can be read and deleted again.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+				Description: "Numeric identifier of this specific Message.",
+			},
+			"application_id": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+					int64planmodifier.RequiresReplace(),
+				},
+				Description:         "Numeric identifier of the application to send the message from. Conflicts with token.",
+				MarkdownDescription: "Numeric identifier of the application to send the message from. Conflicts with `token`, one of them must be set.",
+				Validators: []validator.Int64{
+					int64validator.ExactlyOneOf(path.MatchRoot("token")),
+				},
+			},
+			"token": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description:         "Token of the application to send the message from. Conflicts with application_id.",
+				MarkdownDescription: "Token of the application to send the message from. Conflicts with `application_id`, one of them must be set. The application must belong to the user the provider is configured with.",
+			},
+			"title": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "Title of the message. Defaults to the application name.",
+			},
+			"message": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "The message itself. Markdown is rendered if the extras ask for it.",
+			},
+			"priority": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+					int64planmodifier.RequiresReplace(),
+				},
+				Description: "Priority of the message. Defaults to the applications default priority.",
+			},
+			"extras": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description:         "JSON encoded object of extra data for clients, like markdown rendering or click URLs.",
+				MarkdownDescription: "JSON encoded object of extra data for clients, for example `jsonencode({ \"client::display\" = { contentType = \"text/markdown\" } })`. See the [Gotify documentation](https://gotify.net/docs/msgextras) for supported extras.",
+			},
+			"date": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "RFC3339 timestamp of when the server received the message.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Delete: true}),
+		},
+	}
+}
+
+func (r *MessageResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		// IMPORTANT: This method is called MULTIPLE times. An initial call might not have configured the Provider yet, so we need
+		// to handle this gracefully. It will eventually be called with a configured provider.
+		return
+	}
+
+	client, ok := req.ProviderData.(*internal.AuthedGotifyClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AuthedGotifyClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.gotify = client
+}
+
+func (r *MessageResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data MessageResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := messageExtras(data.Extras); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("extras"), "Invalid message extras", err.Error())
+	}
+}
+
+func (r *MessageResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data MessageResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Creating message", map[string]interface{}{"application_id": data.ApplicationId.ValueInt64()})
+
+	// Messages are always published by the application itself, so we need its token. The message can only be read and
+	// deleted later if the application belongs to the provider user, so a token is checked against its applications
+	app_list, err := r.gotify.GetApps(ctx)
+	if err != nil {
+		resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Root("application_id"), "Gotify API Request failed", err))
+		return
+	}
+	var token string
+	if data.Token.IsNull() {
+		matches := findApplications(app_list, data.ApplicationId, types.StringNull())
+		if len(matches) == 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("application_id"),
+				"Application not found",
+				fmt.Sprintf("No application with id %d exists for the user the provider is configured with.", data.ApplicationId.ValueInt64()),
+			)
+			return
+		}
+		token = matches[0].Token
+	} else {
+		for _, app := range app_list {
+			if app.Token == data.Token.ValueString() {
+				token = app.Token
+			}
+		}
+		if token == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("token"),
+				"Application not found",
+				"The token does not belong to any application of the user the provider is configured with. Gotify only lets users read and delete the messages of their own applications, so the message could not be managed. Configure the provider with the owner of the application, or check whether the token was rotated.",
+			)
+			return
+		}
+	}
+
+	extras, err := messageExtras(data.Extras)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("extras"), "Invalid message extras", err.Error())
+		return
+	}
+	body := &internal.Message{
+		Title:   data.Title.ValueString(),
+		Message: data.Message.ValueString(),
+		Extras:  extras,
+	}
+	if !data.Priority.IsUnknown() {
+		// Otherwise the server uses the applications default priority
+		body.Priority = data.Priority.ValueInt64Pointer()
+	}
+	new_message, err := r.gotify.CreateMessage(ctx, token, body)
+	var apiErr *internal.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized && !data.Token.IsNull() {
+		// Published with the application token, not the credentials of the provider
+		resp.Diagnostics.AddAttributeError(path.Root("token"), "Gotify rejected the application token", fmt.Sprintf("The token does not belong to any application, it might have been rotated or its application deleted.\n\n%s", err.Error()))
+		return
+	} else if err != nil {
+		resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Root("application_id"), "Gotify API Request failed", err))
+		return
+	}
+
+	data.Id = types.Int64Value(int64(new_message.ID))
+	data.ApplicationId = types.Int64Value(int64(new_message.ApplicationID))
+	data.Priority = types.Int64Value(int64(new_message.Priority))
+	data.Date = types.StringValue(new_message.Date.Format(time.RFC3339))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *MessageResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state MessageResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Reading message", map[string]interface{}{"id": state.Id.ValueInt64()})
+
+	// Messages are listed newest first and "since" only returns older ones, so this returns just our message if it still exists
+	since := state.Id.ValueInt64() + 1
+	limit := int64(1)
+	params := message.NewGetAppMessagesParamsWithContext(ctx)
+	params.ID = state.ApplicationId.ValueInt64()
+	params.Since = &since
+	params.Limit = &limit
+	message_list, err := r.gotify.Client.Message.GetAppMessages(params, r.gotify.Auth)
+	var notFound *message.GetAppMessagesNotFound
+	if err != nil && !errors.As(err, &notFound) {
+		resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Root("id"), "Gotify API Request failed", err))
+		return
+	}
+
+	if err == nil && len(message_list.Payload.Messages) > 0 && message_list.Payload.Messages[0].ID == uint(state.Id.ValueInt64()) {
+		// Messages can't be changed, there is nothing to update
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	} else {
+		// The Message (or its whole Application) is no longer there, remove it and let terraform re-create it later.
+		// https://discuss.hashicorp.com/t/how-should-read-signal-that-a-resource-has-vanished-from-the-api-server/40833/2
+		resp.State.RemoveResource(ctx)
+	}
+}
+
+func (r *MessageResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Every argument requires a replacement, since messages can't be changed once sent.
+	var data MessageResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *MessageResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state MessageResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Delete, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Deleting message", map[string]interface{}{"id": state.Id.ValueInt64()})
+
+	params := message.NewDeleteMessageParamsWithContext(ctx)
+	params.ID = state.Id.ValueInt64()
+	_, err := r.gotify.Client.Message.DeleteMessage(params, r.gotify.Auth)
+	var notFound *message.DeleteMessageNotFound
+	if err != nil && !errors.As(err, &notFound) {
+		resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Root("id"), "Gotify API Request failed", err))
+		return
+	}
+}
+
+// Decodes the JSON encoded extras, which must be an object.
+func messageExtras(extras types.String) (map[string]interface{}, error) {
+	if extras.IsNull() || extras.IsUnknown() {
+		return nil, nil
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal([]byte(extras.ValueString()), &decoded); err != nil {
+		return nil, fmt.Errorf("extras must be a JSON encoded object: %w", err)
+	}
+	return decoded, nil
+}
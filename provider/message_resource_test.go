@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestMessageResource(t *testing.T) {
+	testAcc(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Test Create() and Read() by application id
+			{
+				Config: providerConfig + `
+resource "gotify_application" "test" {
+ name = "Messages"
+ default_priority = 3
+}
+
+resource "gotify_message" "test" {
+ application_id = gotify_application.test.id
+ title = "Deployment"
+ message = "Database **migrated**"
+ extras = jsonencode({ "client::display" = { contentType = "text/markdown" } })
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("gotify_message.test", "application_id", "gotify_application.test", "id"),
+					resource.TestCheckResourceAttr("gotify_message.test", "title", "Deployment"),
+					resource.TestCheckResourceAttr("gotify_message.test", "priority", "3"),
+					resource.TestCheckResourceAttrSet("gotify_message.test", "id"),
+					resource.TestCheckResourceAttrSet("gotify_message.test", "date"),
+				),
+			},
+			// Test replacement by token
+			{
+				Config: providerConfig + `
+resource "gotify_application" "test" {
+ name = "Messages"
+ default_priority = 3
+}
+
+resource "gotify_message" "test" {
+ token = gotify_application.test.token
+ message = "Changed"
+ priority = 8
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("gotify_message.test", "application_id", "gotify_application.test", "id"),
+					resource.TestCheckResourceAttr("gotify_message.test", "message", "Changed"),
+					resource.TestCheckResourceAttr("gotify_message.test", "priority", "8"),
+				),
+			},
+		},
+	})
+}
+
+func TestMessageResourceForeignToken(t *testing.T) {
+	fake := newFakeGotify(t)
+	fake.mutate(func(f *fakeGotify) {
+		bob := f.addUser("bob", "bob", false)
+		app := &fakeApp{UserId: bob.ID}
+		app.ID = f.newId()
+		app.Name = "Bob's app"
+		app.Token = "bob-token"
+		f.apps[app.ID] = app
+	})
+	testFake(t, fake, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The admin can't read or delete the message later, it would be sent again on every apply
+			{
+				Config: providerConfig + `
+resource "gotify_message" "test" {
+ token = "bob-token"
+ message = "Hello Bob"
+}
+`,
+				ExpectError: regexp.MustCompile(`(?s)Application not found.*does not belong to any application`),
+			},
+		},
+	})
+
+	fake.mutate(func(f *fakeGotify) {
+		if len(f.messages) > 0 {
+			t.Errorf("Expected no message to be sent with a foreign token, got %d", len(f.messages))
+		}
+	})
+}
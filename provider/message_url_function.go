@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure implementation satisfies expected interfaces - compilition fails here otherwise.
+var _ function.Function = &MessageUrlFunction{}
+
+type MessageUrlFunction struct{}
+
+func NewMessageUrlFunction() function.Function {
+	return &MessageUrlFunction{}
+}
+
+func (f *MessageUrlFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "message_url"
+}
+
+func (f *MessageUrlFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "URL an application can POST messages to",
+		MarkdownDescription: "Builds the URL an application publishes messages to, with the application token as a query parameter. Any base path of the `endpoint` is kept.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "endpoint",
+				MarkdownDescription: "The Gotify endpoint with protocol, like the `endpoint` of the provider.",
+			},
+			function.StringParameter{
+				Name:                "token",
+				MarkdownDescription: "Token of the application, for example the `token` of a `gotify_application`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *MessageUrlFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var endpoint, token string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &endpoint, &token))
+	if resp.Error != nil {
+		return
+	}
+
+	u, err := endpointURL(endpoint, "/message", false)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, withToken(u, token)))
+}
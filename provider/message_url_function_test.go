@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestMessageUrlFunction(t *testing.T) {
+	testAcc(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+ value = provider::gotify::message_url("https://example.com/gotify/", "AbC.123")
+}
+`,
+				Check: resource.TestCheckOutput("test", "https://example.com/gotify/message?token=AbC.123"),
+			},
+			{
+				Config: `
+output "test" {
+ value = provider::gotify::message_url("example.com", "AbC.123")
+}
+`,
+				ExpectError: regexp.MustCompile(`must be an absolute URL`),
+			},
+		},
+	})
+}
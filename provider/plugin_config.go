@@ -0,0 +1,175 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"gopkg.in/yaml.v3"
+)
+
+// Converts the `config` attribute into plain Go values, ready to be sent to Gotify as YAML.
+// Strings are parsed as YAML documents, everything else is taken as an HCL object.
+func pluginConfigValue(ctx context.Context, config types.Dynamic) (interface{}, error) {
+	if config.IsUnknown() || config.IsUnderlyingValueUnknown() {
+		return nil, fmt.Errorf("plugin config is not yet known")
+	}
+	if config.IsNull() || config.IsUnderlyingValueNull() {
+		return nil, nil
+	}
+
+	value, err := config.UnderlyingValue().ToTerraformValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if value.Type().Is(tftypes.String) {
+		var raw string
+		if err := value.As(&raw); err != nil {
+			return nil, err
+		}
+		var parsed interface{}
+		if err := yaml.Unmarshal([]byte(raw), &parsed); err != nil {
+			return nil, fmt.Errorf("plugin config is not valid YAML: %w", err)
+		}
+		return normalizeYAML(parsed)
+	}
+
+	converted, err := fromTerraformValue(value)
+	if err != nil {
+		return nil, err
+	}
+	return normalizeYAML(converted)
+}
+
+// Round-trips the value through YAML, so that values from HCL, YAML and the server compare equal.
+func normalizeYAML(value interface{}) (interface{}, error) {
+	encoded, err := yaml.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var normalized interface{}
+	if err := yaml.Unmarshal(encoded, &normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// Semantic comparison of two plugin configs, ignoring YAML formatting and key order.
+func pluginConfigEqual(a interface{}, b interface{}) (bool, error) {
+	normalizedA, err := normalizeYAML(a)
+	if err != nil {
+		return false, err
+	}
+	normalizedB, err := normalizeYAML(b)
+	if err != nil {
+		return false, err
+	}
+	return reflect.DeepEqual(normalizedA, normalizedB), nil
+}
+
+func fromTerraformValue(value tftypes.Value) (interface{}, error) {
+	if value.IsNull() {
+		return nil, nil
+	}
+	if !value.IsKnown() {
+		return nil, fmt.Errorf("plugin config contains values that are not yet known")
+	}
+
+	typ := value.Type()
+	switch {
+	case typ.Is(tftypes.String):
+		var str string
+		err := value.As(&str)
+		return str, err
+	case typ.Is(tftypes.Bool):
+		var b bool
+		err := value.As(&b)
+		return b, err
+	case typ.Is(tftypes.Number):
+		num := new(big.Float)
+		if err := value.As(&num); err != nil {
+			return nil, err
+		}
+		if num.IsInt() {
+			i, _ := num.Int64()
+			return i, nil
+		}
+		f, _ := num.Float64()
+		return f, nil
+	case typ.Is(tftypes.Object{}) || typ.Is(tftypes.Map{}):
+		var attrs map[string]tftypes.Value
+		if err := value.As(&attrs); err != nil {
+			return nil, err
+		}
+		result := make(map[string]interface{}, len(attrs))
+		for key, attr := range attrs {
+			converted, err := fromTerraformValue(attr)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = converted
+		}
+		return result, nil
+	case typ.Is(tftypes.List{}) || typ.Is(tftypes.Tuple{}) || typ.Is(tftypes.Set{}):
+		var elems []tftypes.Value
+		if err := value.As(&elems); err != nil {
+			return nil, err
+		}
+		result := make([]interface{}, 0, len(elems))
+		for _, elem := range elems {
+			converted, err := fromTerraformValue(elem)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, converted)
+		}
+		return result, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %s in plugin config", typ)
+	}
+}
+
+// Encodes the config from the server as YAML to store it in state.
+func pluginConfigString(value interface{}) (types.Dynamic, error) {
+	encoded, err := yaml.Marshal(value)
+	if err != nil {
+		return types.DynamicNull(), err
+	}
+	return types.DynamicValue(types.StringValue(string(encoded))), nil
+}
+
+// Plans the config from state when the configured one is semantically equal, so that switching between an HCL object
+// and a YAML string or reformatting the YAML does not show up as a change.
+type pluginConfigPlanModifier struct{}
+
+func (m pluginConfigPlanModifier) Description(_ context.Context) string {
+	return "Differences in YAML formatting or key order are not considered changes."
+}
+
+func (m pluginConfigPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m pluginConfigPlanModifier) PlanModifyDynamic(ctx context.Context, req planmodifier.DynamicRequest, resp *planmodifier.DynamicResponse) {
+	if req.StateValue.IsNull() || req.PlanValue.IsNull() || req.PlanValue.IsUnknown() || req.PlanValue.IsUnderlyingValueUnknown() {
+		return
+	}
+
+	// Invalid configs are reported when they are applied
+	planned, err := pluginConfigValue(ctx, req.PlanValue)
+	if err != nil {
+		return
+	}
+	current, err := pluginConfigValue(ctx, req.StateValue)
+	if err != nil {
+		return
+	}
+	if equal, err := pluginConfigEqual(planned, current); err == nil && equal {
+		resp.PlanValue = req.StateValue
+	}
+}
@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPluginConfigSemanticEquality(t *testing.T) {
+	ctx := context.Background()
+
+	fromYAML, err := pluginConfigValue(ctx, types.DynamicValue(types.StringValue("retries: 3\nchannels:\n  - alerts\n  - ops\nverbose: true\n")))
+	if err != nil {
+		t.Fatalf("Could not parse YAML config: %v", err.Error())
+	}
+
+	fromHCL, err := pluginConfigValue(ctx, types.DynamicValue(types.ObjectValueMust(
+		map[string]attr.Type{
+			"verbose":  types.BoolType,
+			"retries":  types.NumberType,
+			"channels": types.TupleType{ElemTypes: []attr.Type{types.StringType, types.StringType}},
+		},
+		map[string]attr.Value{
+			"verbose":  types.BoolValue(true),
+			"retries":  types.NumberValue(big.NewFloat(3)),
+			"channels": types.TupleValueMust([]attr.Type{types.StringType, types.StringType}, []attr.Value{types.StringValue("alerts"), types.StringValue("ops")}),
+		},
+	)))
+	if err != nil {
+		t.Fatalf("Could not convert HCL config: %v", err.Error())
+	}
+
+	equal, err := pluginConfigEqual(fromYAML, fromHCL)
+	if err != nil {
+		t.Fatalf("Could not compare configs: %v", err.Error())
+	}
+	if !equal {
+		t.Errorf("Expected YAML %v and HCL %v configs to be equal", fromYAML, fromHCL)
+	}
+
+	changed, err := pluginConfigValue(ctx, types.DynamicValue(types.StringValue("{retries: 4, channels: [alerts, ops], verbose: true}")))
+	if err != nil {
+		t.Fatalf("Could not parse YAML config: %v", err.Error())
+	}
+	equal, err = pluginConfigEqual(fromYAML, changed)
+	if err != nil {
+		t.Fatalf("Could not compare configs: %v", err.Error())
+	}
+	if equal {
+		t.Errorf("Expected configs with different values to differ")
+	}
+}
+
+func TestPluginConfigInvalidYAML(t *testing.T) {
+	_, err := pluginConfigValue(context.Background(), types.DynamicValue(types.StringValue("channels: [unclosed")))
+	if err == nil {
+		t.Fatal("Expected an error for invalid YAML, got none")
+	}
+}
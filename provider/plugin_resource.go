@@ -2,22 +2,31 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"slices"
+	"strings"
 	"terraform-provider-gotify/provider/internal"
 
-	"github.com/go-openapi/runtime"
 	"github.com/gotify/go-api-client/v2/client/plugin"
 	"github.com/gotify/go-api-client/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var (
 	// Ensure implementation satisfies expected interfaces - compilition fails here otherwise.
-	_ resource.Resource              = &PluginResource{}
-	_ resource.ResourceWithConfigure = &PluginResource{}
+	_ resource.Resource                = &PluginResource{}
+	_ resource.ResourceWithConfigure   = &PluginResource{}
+	_ resource.ResourceWithImportState = &PluginResource{}
+	_ resource.ResourceWithModifyPlan  = &PluginResource{}
 )
 
 type PluginResource struct {
@@ -29,8 +38,11 @@ func NewPluginResource() resource.Resource {
 }
 
 type PluginResourceModel struct {
-	ModulePath types.String `tfsdk:"module_path"`
-	Enabled    types.Bool   `tfsdk:"enabled"`
+	ModulePath types.String   `tfsdk:"module_path"`
+	Enabled    types.Bool     `tfsdk:"enabled"`
+	Config     types.Dynamic  `tfsdk:"config"`
+	StoreToken types.Bool     `tfsdk:"store_token"`
+	Timeouts   timeouts.Value `tfsdk:"timeouts"`
 	// Read-only after apply
 	Token       types.String `tfsdk:"token"`
 	WebhookPath types.String `tfsdk:"webhook_path"`
@@ -43,7 +55,7 @@ func (r *PluginResource) Metadata(ctx context.Context, req resource.MetadataRequ
 func (r *PluginResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description:         "Configures a plugin installed on the Gotify server.",
-		MarkdownDescription: "The plugin must already be on the server. It must be compatible as well, you can check this manually by navigating to \"Plugins\" in the Web interface.",
+		MarkdownDescription: "The plugin must already be on the server. The `module_path` is checked against the installed plugins during plan, unless the server can not be reached yet or `wait_for_ready` is still waiting for it. Then the check happens during apply. Gotify does not list plugins that are incompatible or failed to load, check the server log if your plugin is missing. Plugins that are listed without any capabilities are reported with a warning.\n\nExisting plugins can be imported by their `module_path`.",
 		Attributes: map[string]schema.Attribute{
 			"module_path": schema.StringAttribute{
 				Required:    true,
@@ -53,19 +65,31 @@ func (r *PluginResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Required:    true,
 				Description: "Sets the desired plugin status.",
 			},
+			"config": schema.DynamicAttribute{
+				Optional:            true,
+				Description:         "The plugin configuration, either as a YAML string or as an object that is encoded to YAML.",
+				MarkdownDescription: "The plugin configuration, either as a YAML string or as an object that is encoded to YAML. Only plugins with the `configurer` capability can be configured.\n\nDifferences in YAML formatting or key order are not considered changes. Removing the attribute leaves the current configuration on the server untouched.",
+				PlanModifiers: []planmodifier.Dynamic{
+					pluginConfigPlanModifier{},
+				},
+			},
 			"token": schema.StringAttribute{
 				Computed:    true,
 				Sensitive:   true,
 				Description: "The token generated for this plugin. Mainly used for Webhooks.",
 			},
+			"store_token": storeTokenAttribute("plugin"),
 			"webhook_path": schema.StringAttribute{
 				Computed:            true,
 				Sensitive:           true,
 				Optional:            true,
 				Description:         "Generates the webhook base path. If the plugin registers a webhook, this is where it'll be available at.",
-				MarkdownDescription: "You are responsible for setting the host/port AND the sub-path the plugin sets itself. Usually, the plugin description has more information, check \"Plugins\" in the Web interface.\n\nFor example, if the full plugin webhook path is `https://localhost:8080/plugin/1/custom/t0k3n/slack_message` then this field will contain `/plugin/1/custom/t0k3n`\n\nNOTE: The path **does** include a leading slash but **not** a trailing slash.",
+				MarkdownDescription: "You are responsible for setting the host/port AND the sub-path the plugin sets itself. Usually, the plugin description has more information, check \"Plugins\" in the Web interface.\n\nFor example, if the full plugin webhook path is `https://localhost:8080/plugin/1/custom/t0k3n/slack_message` then this field will contain `/plugin/1/custom/t0k3n`\n\nNOTE: The path **does** include a leading slash but **not** a trailing slash. It contains the token, so it is null if `store_token` is `false`.",
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Update: true}),
+		},
 	}
 }
 
@@ -99,73 +123,142 @@ func (r *PluginResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Creating plugin", map[string]interface{}{"module_path": data.ModulePath.ValueString()})
+
 	// 1. Find plugin ID
-	found, err := r.findPlugin(data.ModulePath.ValueString())
+	plugin_list, err := r.gotify.GetPlugins(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Could not fetch plugin list", err.Error())
+		resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Root("module_path"), "Could not fetch plugin list", err))
 		return
-	} else if found == nil {
-		resp.Diagnostics.AddError("Could not find plugin %s, is it installed?", data.ModulePath.String())
+	}
+	found := findPlugin(plugin_list, data.ModulePath.ValueString())
+	if found == nil {
+		resp.Diagnostics.AddAttributeError(path.Root("module_path"), "Plugin is not installed", pluginNotInstalled(plugin_list, data.ModulePath.ValueString()))
 		return
 	}
 
-	// 2. Enable/Disable the plugin
+	// 2. Push the configuration, before the plugin is enabled with it
+	if !data.Config.IsNull() {
+		err = r.applyPluginConfig(ctx, found, data.Config)
+		if err != nil {
+			resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Root("config"), "Could not configure plugin", err))
+			return
+		}
+	}
+
+	// 3. Enable/Disable the plugin
 	if found.Enabled != data.Enabled.ValueBool() {
-		err = r.applyPluginState(int64(found.ID), data.Enabled.ValueBool())
+		err = r.applyPluginState(ctx, int64(found.ID), data.Enabled.ValueBool())
 		if err != nil {
-			resp.Diagnostics.AddError("Could not enable/disable plugin", err.Error())
+			resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Root("enabled"), "Could not enable/disable plugin", err))
 			return
 		}
 	}
 
 	// Store state info
-	data.Token = types.StringValue(found.Token)
-	data.WebhookPath = toWebhookPath(found.ID, found.Token)
+	data.Token = storedToken(data.StoreToken, found.Token)
+	data.WebhookPath = toWebhookPath(found.ID, data.Token)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-func (r *PluginResource) applyPluginState(id int64, enable bool) error {
+func (r *PluginResource) applyPluginState(ctx context.Context, id int64, enable bool) error {
 	var err error
 	if enable {
-		params := plugin.NewEnablePluginParams()
+		params := plugin.NewEnablePluginParamsWithContext(ctx)
 		params.ID = id
 		_, err = r.gotify.Client.Plugin.EnablePlugin(params, r.gotify.Auth)
 	} else {
-		params := plugin.NewDisablePluginParams()
+		params := plugin.NewDisablePluginParamsWithContext(ctx)
 		params.ID = id
 		_, err = r.gotify.Client.Plugin.DisablePlugin(params, r.gotify.Auth)
 	}
-	if err == nil {
+	var apiErr *internal.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusBadRequest {
+		// Plugin is already enabled/disabled, ignore and continue
 		return nil
-	} else {
-		apiErr, ok := err.(*runtime.APIError)
-		if ok && apiErr.Code == 400 {
-			// Plugin is already enabled/disabled, ignore and continue
-			return nil
-		} else {
-			return err
-		}
 	}
+	return err
 }
 
-func (r *PluginResource) findPlugin(modulePath string) (*models.PluginConfExternal, error) {
-	params := plugin.NewGetPluginsParams()
-	plugins, err := r.gotify.Client.Plugin.GetPlugins(params, r.gotify.Auth)
+func (r *PluginResource) applyPluginConfig(ctx context.Context, found *models.PluginConfExternal, config types.Dynamic) error {
+	if !slices.Contains(found.Capabilities, pluginCapabilityConfigurer) {
+		return fmt.Errorf("plugin %s can not be configured, it does not have the %q capability", found.ModulePath, pluginCapabilityConfigurer)
+	}
+
+	value, err := pluginConfigValue(ctx, config)
 	if err != nil {
-		return nil, err
-	} else {
-		for _, plugin := range plugins.Payload {
-			if plugin.ModulePath == modulePath {
-				return plugin, nil
-			}
+		return err
+	}
+
+	return r.gotify.UpdatePluginConfig(ctx, int64(found.ID), value)
+}
+
+// Replaces the config in state with the one from the server, unless they are semantically equal.
+func (r *PluginResource) readPluginConfig(ctx context.Context, found *models.PluginConfExternal, state *PluginResourceModel) error {
+	params := plugin.NewGetPluginConfigParamsWithContext(ctx)
+	params.ID = int64(found.ID)
+	current, err := r.gotify.Client.Plugin.GetPluginConfig(params, r.gotify.Auth)
+	if err != nil {
+		return err
+	}
+
+	desired, err := pluginConfigValue(ctx, state.Config)
+	if err != nil {
+		return err
+	}
+	equal, err := pluginConfigEqual(desired, current.Payload)
+	if err != nil {
+		return err
+	}
+	if !equal {
+		state.Config, err = pluginConfigString(current.Payload)
+	}
+	return err
+}
+
+// Finds the plugin with the given module path, nil if it is not installed.
+func findPlugin(plugins []*models.PluginConfExternal, modulePath string) *models.PluginConfExternal {
+	for _, plugin := range plugins {
+		if plugin.ModulePath == modulePath {
+			return plugin
 		}
-		return nil, nil
 	}
+	return nil
 }
 
-func toWebhookPath(id uint, token string) basetypes.StringValue {
-	return types.StringValue(fmt.Sprintf("/plugin/%v/custom/%v", id, token))
+// Explains that the plugin is not installed, listing the ones that are to spot typos.
+func pluginNotInstalled(plugins []*models.PluginConfExternal, modulePath string) string {
+	installed := "There are no plugins installed."
+	if len(plugins) > 0 {
+		paths := make([]string, 0, len(plugins))
+		for _, plugin := range plugins {
+			paths = append(paths, fmt.Sprintf("%q", plugin.ModulePath))
+		}
+		installed = fmt.Sprintf("The installed plugins are %s.", strings.Join(paths, ", "))
+	}
+	return fmt.Sprintf("There is no plugin with module path %q on the server. %s Gotify does not list plugins that are incompatible with the server or fail to load, check the server log for errors in that case.", modulePath, installed)
+}
+
+// Plugins with this capability accept a YAML configuration.
+const pluginCapabilityConfigurer = "configurer"
+
+// The webhook path contains the token, so it's only known if the token is.
+func toWebhookPath(id uint, token types.String) basetypes.StringValue {
+	if token.IsNull() {
+		return types.StringNull()
+	}
+	return types.StringValue(webhookPath(int64(id), token.ValueString()))
+}
+
+// Path of a plugins custom webhook handlers, relative to the endpoint.
+func webhookPath(id int64, token string) string {
+	return fmt.Sprintf("/plugin/%v/custom/%v", id, token)
 }
 
 func (r *PluginResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -176,16 +269,32 @@ func (r *PluginResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	// Find this application and it's data
-	found, err := r.findPlugin(state.ModulePath.ValueString())
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Reading plugin", map[string]interface{}{"module_path": state.ModulePath.ValueString()})
+
+	// Find this plugin and it's data
+	plugin_list, err := r.gotify.GetPlugins(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Gotify API Request failed", err.Error())
-	} else if found != nil {
+		resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Root("module_path"), "Gotify API Request failed", err))
+	} else if found := findPlugin(plugin_list, state.ModulePath.ValueString()); found != nil {
 		// Update information on state
-		state.Token = types.StringValue(found.Token)
 		state.Enabled = types.BoolValue(found.Enabled)
-		state.Token = types.StringValue(found.Token)
-		state.WebhookPath = toWebhookPath(found.ID, found.Token)
+		state.StoreToken = types.BoolValue(storeToken(state.StoreToken))
+		state.Token = storedToken(state.StoreToken, found.Token)
+		state.WebhookPath = toWebhookPath(found.ID, state.Token)
+
+		// Only track the config if it's managed by Terraform
+		if !state.Config.IsNull() {
+			err = r.readPluginConfig(ctx, found, &state)
+			if err != nil {
+				resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Root("config"), "Could not read plugin config", err))
+				return
+			}
+		}
 
 		// Write new information to tf-state
 		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
@@ -205,25 +314,42 @@ func (r *PluginResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	found, err := r.findPlugin(plan.ModulePath.ValueString())
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.Update, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Updating plugin", map[string]interface{}{"module_path": plan.ModulePath.ValueString()})
+
+	plugin_list, err := r.gotify.GetPlugins(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Gotify API request failed", err.Error())
+		resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Root("module_path"), "Gotify API Request failed", err))
 		return
-	} else if found == nil {
-		resp.Diagnostics.AddError("Could not find plugin %s, is it installed?", plan.ModulePath.ValueString())
+	}
+	found := findPlugin(plugin_list, plan.ModulePath.ValueString())
+	if found == nil {
+		resp.Diagnostics.AddAttributeError(path.Root("module_path"), "Plugin is not installed", pluginNotInstalled(plugin_list, plan.ModulePath.ValueString()))
 		return
 	}
 
+	if !plan.Config.IsNull() && !plan.Config.Equal(state.Config) {
+		err := r.applyPluginConfig(ctx, found, plan.Config)
+		if err != nil {
+			resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Root("config"), "Could not configure plugin", err))
+			return
+		}
+	}
+
 	if !plan.Enabled.Equal(state.Enabled) {
-		err := r.applyPluginState(int64(found.ID), plan.Enabled.ValueBool())
+		err := r.applyPluginState(ctx, int64(found.ID), plan.Enabled.ValueBool())
 		if err != nil {
-			resp.Diagnostics.AddError("Gotify API Request failed", err.Error())
+			resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Root("enabled"), "Gotify API Request failed", err))
 			return
 		}
 	}
 
-	plan.Token = types.StringValue(found.Token)
-	plan.WebhookPath = toWebhookPath(found.ID, found.Token)
+	plan.Token = storedToken(plan.StoreToken, found.Token)
+	plan.WebhookPath = toWebhookPath(found.ID, plan.Token)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
@@ -234,3 +360,83 @@ func (r *PluginResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		"This will only remove the plugin from your Terraform State. It will not disable the plugin, nor will it uninstall it. If you want to disable the plugin, add the `gotify_plugin` resource with `enabled = false`. If you want to uninstall the plugin, remove the so file from the Gotify Plugin path.",
 	)
 }
+
+func (r *PluginResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		// Nothing to check when destroying
+		return
+	}
+
+	var plan PluginResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state PluginResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		// The token of a plugin never changes. Keep it, so that a config which only differs in formatting is no change
+		if plan.StoreToken.Equal(state.StoreToken) {
+			if plan.Token.IsUnknown() {
+				plan.Token = state.Token
+			}
+			if plan.WebhookPath.IsUnknown() {
+				plan.WebhookPath = state.WebhookPath
+			}
+			resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		}
+	}
+
+	if r.gotify == nil || plan.ModulePath.IsUnknown() {
+		// Nothing to check before the provider is configured
+		return
+	}
+	if !r.gotify.Ready() {
+		// Don't wait for a server that is only deployed during the apply, Create() reports missing plugins then
+		tflog.Debug(ctx, "Skipping the plugin check, Gotify is not ready yet")
+		return
+	}
+
+	// Catch typos in the module path during plan, instead of failing halfway through the apply
+	plugin_list, err := r.gotify.GetPlugins(ctx)
+	var apiErr *internal.APIError
+	if err != nil && !errors.As(err, &apiErr) && ctx.Err() == nil {
+		// Not reachable, like a server that is only deployed during the apply. Create() reports missing plugins then
+		tflog.Debug(ctx, "Skipping the plugin check, Gotify can not be reached", map[string]interface{}{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Root("module_path"), "Gotify API Request failed", err))
+		return
+	}
+	found := findPlugin(plugin_list, plan.ModulePath.ValueString())
+	if found == nil {
+		resp.Diagnostics.AddAttributeError(path.Root("module_path"), "Plugin is not installed", pluginNotInstalled(plugin_list, plan.ModulePath.ValueString()))
+		return
+	}
+
+	if len(found.Capabilities) == 0 {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("module_path"),
+			"Plugin has no capabilities",
+			fmt.Sprintf("Plugin %s is installed, but reports no capabilities, so enabling it has no effect. It was probably built for a different Gotify version, check the server log for errors when it was loaded.", found.ModulePath),
+		)
+	}
+
+	if !plan.Config.IsNull() && !slices.Contains(found.Capabilities, pluginCapabilityConfigurer) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("config"),
+			"Plugin can not be configured",
+			fmt.Sprintf("Plugin %s does not have the %q capability, remove the config attribute.", found.ModulePath, pluginCapabilityConfigurer),
+		)
+	}
+}
+
+func (r *PluginResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Read() looks up the plugin by module_path and fills in everything else
+	resource.ImportStatePassthroughID(ctx, path.Root("module_path"), req, resp)
+}
@@ -1,13 +1,20 @@
 package provider
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestPluginResource(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+	testAcc(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
@@ -39,6 +46,225 @@ resource "gotify_plugin" "test" {
 					resource.TestCheckResourceAttr("gotify_plugin.test", "enabled", "false"),
 				),
 			},
+			// Test ImportState()
+			{
+				ResourceName:                         "gotify_plugin.test",
+				ImportState:                          true,
+				ImportStateId:                        "github.com/LukasKnuth/gotify-slack-webhook",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "module_path",
+			},
+		},
+	})
+}
+
+func TestPluginResourceConfig(t *testing.T) {
+	fake := newFakeGotify(t)
+	testFake(t, fake, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Config as HCL object
+			{
+				Config: providerConfig + `
+resource "gotify_plugin" "test" {
+ module_path = "github.com/LukasKnuth/gotify-slack-webhook"
+ enabled = true
+ config = {
+  channels = ["alerts", "ops"]
+ }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("gotify_plugin.test", "config.channels.#", "2"),
+				),
+			},
+			// Same config as YAML string, only the formatting differs
+			{
+				Config: providerConfig + `
+resource "gotify_plugin" "test" {
+ module_path = "github.com/LukasKnuth/gotify-slack-webhook"
+ enabled = true
+ config = "channels:\n  - alerts\n  - ops\n"
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// The server formats the same config differently
+			{
+				PreConfig: func() {
+					fake.mutate(func(f *fakeGotify) {
+						for _, plugin := range f.plugins {
+							plugin.Config = "channels: [alerts, ops]\n"
+						}
+					})
+				},
+				Config: providerConfig + `
+resource "gotify_plugin" "test" {
+ module_path = "github.com/LukasKnuth/gotify-slack-webhook"
+ enabled = true
+ config = "channels:\n  - alerts\n  - ops\n"
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// Changed in the web UI, Terraform changes it back
+			{
+				PreConfig: func() {
+					fake.mutate(func(f *fakeGotify) {
+						for _, plugin := range f.plugins {
+							plugin.Config = "channels: [random]\n"
+						}
+					})
+				},
+				Config: providerConfig + `
+resource "gotify_plugin" "test" {
+ module_path = "github.com/LukasKnuth/gotify-slack-webhook"
+ enabled = true
+ config = "channels:\n  - alerts\n  - ops\n"
+}
+`,
+				Check: func(_ *terraform.State) error {
+					var config string
+					fake.mutate(func(f *fakeGotify) {
+						for _, plugin := range f.plugins {
+							config = plugin.Config
+						}
+					})
+					if !strings.Contains(config, "alerts") {
+						return fmt.Errorf("expected drift to be reverted, got config %q", config)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestPluginResourceNotInstalled(t *testing.T) {
+	fake := newFakeGotify(t)
+	testFake(t, fake, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "gotify_plugin" "test" {
+ module_path = "github.com/LukasKnuth/gotify-slack-webhok"
+ enabled = true
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`(?s)Plugin is not installed.*github.com/LukasKnuth/gotify-slack-webhook`),
+			},
+		},
+	})
+}
+
+func TestPluginResourceNotConfigurable(t *testing.T) {
+	fake := newFakeGotify(t)
+	fake.mutate(func(f *fakeGotify) {
+		for _, plugin := range f.plugins {
+			plugin.Capabilities = []string{"webhooker"}
+		}
+	})
+	testFake(t, fake, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "gotify_plugin" "test" {
+ module_path = "github.com/LukasKnuth/gotify-slack-webhook"
+ enabled = true
+ config = "channels: [alerts]\n"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Plugin can not be configured"),
+			},
+		},
+	})
+}
+
+func TestPluginResourceNoCapabilities(t *testing.T) {
+	fake := newFakeGotify(t)
+	fake.mutate(func(f *fakeGotify) {
+		for _, plugin := range f.plugins {
+			plugin.Capabilities = nil
+		}
+	})
+	testFake(t, fake, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Only a warning, the plugin can still be managed
+			{
+				Config: providerConfig + `
+resource "gotify_plugin" "test" {
+ module_path = "github.com/LukasKnuth/gotify-slack-webhook"
+ enabled = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("gotify_plugin.test", "enabled", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestPluginResourceServerNotDeployed(t *testing.T) {
+	// Like a server that is only deployed during the apply
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+
+	testFake(t, newFakeGotify(t), resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Planning does not wait for the server
+			{
+				Config: fmt.Sprintf(`
+provider "gotify" {
+ endpoint = %q
+ username = "admin"
+ password = "admin"
+ wait_for_ready {
+  timeout = 2
+  poll_interval = 1
+ }
+}
+
+resource "gotify_plugin" "test" {
+ module_path = "github.com/LukasKnuth/gotify-slack-webhook"
+ enabled = true
+}
+`, unreachable.URL),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Nor does it fail without waiting
+			{
+				Config: fmt.Sprintf(`
+provider "gotify" {
+ endpoint = %q
+ username = "admin"
+ password = "admin"
+ max_retries = 0
+}
+
+resource "gotify_plugin" "test" {
+ module_path = "github.com/LukasKnuth/gotify-slack-webhook"
+ enabled = true
+}
+`, unreachable.URL),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
 		},
 	})
 }
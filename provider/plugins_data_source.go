@@ -0,0 +1,191 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-gotify/provider/internal"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	// Ensure implementation satisfies expected interfaces - compilition fails here otherwise.
+	_ datasource.DataSource              = &PluginsDataSource{}
+	_ datasource.DataSourceWithConfigure = &PluginsDataSource{}
+)
+
+type PluginsDataSource struct {
+	gotify *internal.AuthedGotifyClient
+}
+
+func NewPluginsDataSource() datasource.DataSource {
+	return &PluginsDataSource{}
+}
+
+type PluginsDataSourceModel struct {
+	// Filters
+	NameRegex types.String `tfsdk:"name_regex"`
+	Enabled   types.Bool   `tfsdk:"enabled"`
+	// Options
+	StoreToken types.Bool `tfsdk:"store_token"`
+	// Read-only
+	Plugins []PluginsDataSourceItemModel `tfsdk:"plugins"`
+}
+
+type PluginsDataSourceItemModel struct {
+	Id           types.Int64    `tfsdk:"id"`
+	ModulePath   types.String   `tfsdk:"module_path"`
+	Name         types.String   `tfsdk:"name"`
+	Author       types.String   `tfsdk:"author"`
+	Website      types.String   `tfsdk:"website"`
+	License      types.String   `tfsdk:"license"`
+	Capabilities []types.String `tfsdk:"capabilities"`
+	Enabled      types.Bool     `tfsdk:"enabled"`
+	Token        types.String   `tfsdk:"token"`
+	WebhookPath  types.String   `tfsdk:"webhook_path"`
+}
+
+func (d *PluginsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_plugins"
+}
+
+func (d *PluginsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "Lists all plugins installed on the Gotify server, optionally filtered.",
+		MarkdownDescription: "Lists all plugins installed on the Gotify server. Use the optional filters to narrow down the list, all given filters must match.",
+		Attributes: map[string]schema.Attribute{
+			"name_regex":  nameRegexAttribute("plugins"),
+			"store_token": storeTokenDataSourceAttribute(),
+			"enabled": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Only list plugins that are (or are not) enabled.",
+			},
+			"plugins": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "All plugins matching the filters.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Numeric identifier of the plugin.",
+						},
+						"module_path": schema.StringAttribute{
+							Computed:    true,
+							Description: "The unique identifier of this plugin, chosen by the author.",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Display name of the plugin.",
+						},
+						"author": schema.StringAttribute{
+							Computed:    true,
+							Description: "Author of the plugin.",
+						},
+						"website": schema.StringAttribute{
+							Computed:    true,
+							Description: "Website of the plugin.",
+						},
+						"license": schema.StringAttribute{
+							Computed:    true,
+							Description: "License of the plugin.",
+						},
+						"capabilities": schema.ListAttribute{
+							Computed:    true,
+							ElementType: types.StringType,
+							Description: "Capabilities of the plugin, for example \"webhooker\" or \"configurer\".",
+						},
+						"enabled": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the plugin is enabled.",
+						},
+						"token": schema.StringAttribute{
+							Computed:    true,
+							Sensitive:   true,
+							Description: "The token generated for this plugin. Mainly used for Webhooks.",
+						},
+						"webhook_path": schema.StringAttribute{
+							Computed:    true,
+							Sensitive:   true,
+							Description: "The webhook base path of the plugin, see the `gotify_plugin` resource for details.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *PluginsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		// IMPORTANT: This method is called MULTIPLE times. An initial call might not have configured the Provider yet, so we need
+		// to handle this gracefully. It will eventually be called with a configured provider.
+		return
+	}
+
+	client, ok := req.ProviderData.(*internal.AuthedGotifyClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *AuthedGotifyClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.gotify = client
+}
+
+func (d *PluginsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PluginsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameRegex, diags := compileNameRegex(data.NameRegex)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plugins, err := d.gotify.GetPlugins(ctx)
+	if err != nil {
+		resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Empty(), "Gotify API Request failed", err))
+		return
+	}
+
+	data.Plugins = []PluginsDataSourceItemModel{}
+	for _, plugin := range plugins {
+		if nameRegex != nil && !nameRegex.MatchString(plugin.Name) {
+			continue
+		}
+		if !data.Enabled.IsNull() && plugin.Enabled != data.Enabled.ValueBool() {
+			continue
+		}
+
+		capabilities := make([]types.String, 0, len(plugin.Capabilities))
+		for _, capability := range plugin.Capabilities {
+			capabilities = append(capabilities, types.StringValue(capability))
+		}
+
+		data.Plugins = append(data.Plugins, PluginsDataSourceItemModel{
+			Id:           types.Int64Value(int64(plugin.ID)),
+			ModulePath:   types.StringValue(plugin.ModulePath),
+			Name:         types.StringValue(plugin.Name),
+			Author:       types.StringValue(plugin.Author),
+			Website:      types.StringValue(plugin.Website),
+			License:      types.StringValue(plugin.License),
+			Capabilities: capabilities,
+			Enabled:      types.BoolValue(plugin.Enabled),
+			Token:        storedToken(data.StoreToken, plugin.Token),
+			WebhookPath:  toWebhookPath(plugin.ID, storedToken(data.StoreToken, plugin.Token)),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestPluginsDataSource(t *testing.T) {
+	testAcc(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "gotify_plugin" "test" {
+ module_path = "github.com/LukasKnuth/gotify-slack-webhook"
+ enabled = true
+}
+
+data "gotify_plugins" "enabled" {
+ enabled = true
+ depends_on = [gotify_plugin.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.gotify_plugins.enabled", "plugins.#", "1"),
+					resource.TestCheckResourceAttr("data.gotify_plugins.enabled", "plugins.0.module_path", "github.com/LukasKnuth/gotify-slack-webhook"),
+					resource.TestCheckResourceAttr("data.gotify_plugins.enabled", "plugins.0.enabled", "true"),
+					resource.TestCheckResourceAttrPair("data.gotify_plugins.enabled", "plugins.0.token", "gotify_plugin.test", "token"),
+					resource.TestCheckResourceAttrPair("data.gotify_plugins.enabled", "plugins.0.webhook_path", "gotify_plugin.test", "webhook_path"),
+				),
+			},
+			// Keep the tokens out of the state
+			{
+				Config: providerConfig + `
+resource "gotify_plugin" "test" {
+ module_path = "github.com/LukasKnuth/gotify-slack-webhook"
+ enabled = true
+}
+
+data "gotify_plugins" "enabled" {
+ enabled = true
+ store_token = false
+ depends_on = [gotify_plugin.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.gotify_plugins.enabled", "plugins.#", "1"),
+					resource.TestCheckNoResourceAttr("data.gotify_plugins.enabled", "plugins.0.token"),
+					resource.TestCheckNoResourceAttr("data.gotify_plugins.enabled", "plugins.0.webhook_path"),
+				),
+			},
+		},
+	})
+}
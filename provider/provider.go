@@ -2,20 +2,35 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"sort"
+	"strconv"
 	"terraform-provider-gotify/provider/internal"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure provider satisfies interfaces (will error compilition here).
-var _ provider.Provider = &GotifyProvider{}
+var (
+	_ provider.Provider                       = &GotifyProvider{}
+	_ provider.ProviderWithFunctions          = &GotifyProvider{}
+	_ provider.ProviderWithEphemeralResources = &GotifyProvider{}
+)
 
 type GotifyProvider struct {
 	version string
@@ -23,12 +38,41 @@ type GotifyProvider struct {
 
 // Map Terraform HCL schema to Go types.
 type GotifyProviderModel struct {
-	Endpoint   types.String `tfsdk:"endpoint"`
-	HostHeader types.String `tfsdk:"host_header"`
-	Username   types.String `tfsdk:"username"`
-	Password   types.String `tfsdk:"password"`
+	Endpoint    types.String `tfsdk:"endpoint"`
+	HostHeader  types.String `tfsdk:"host_header"`
+	Username    types.String `tfsdk:"username"`
+	Password    types.String `tfsdk:"password"`
+	ClientToken types.String `tfsdk:"client_token"`
+	// TLS
+	CACertificate      types.String `tfsdk:"ca_certificate"`
+	ClientCertificate  types.String `tfsdk:"client_certificate"`
+	ClientKey          types.String `tfsdk:"client_key"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	TLSServerName      types.String `tfsdk:"tls_server_name"`
+	// Retries
+	MaxRetries   types.Int64 `tfsdk:"max_retries"`
+	RetryMaxWait types.Int64 `tfsdk:"retry_max_wait"`
+	// Timeouts
+	RequestTimeout types.Int64 `tfsdk:"request_timeout"`
+	// Optional block, nil if not configured
+	WaitForReady *WaitForReadyModel `tfsdk:"wait_for_ready"`
+}
+
+type WaitForReadyModel struct {
+	Timeout      types.Int64 `tfsdk:"timeout"`
+	PollInterval types.Int64 `tfsdk:"poll_interval"`
 }
 
+const (
+	defaultMaxRetries   = 3
+	maxRetriesLimit     = 100
+	defaultRetryMaxWait = 30 * time.Second
+	// Same as the generated API client used before it was configurable
+	defaultRequestTimeout = 30 * time.Second
+	defaultReadyTimeout   = 5 * time.Minute
+	defaultReadyPoll      = 5 * time.Second
+)
+
 func (p *GotifyProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
 	resp.TypeName = "gotify"
 	resp.Version = p.version
@@ -43,7 +87,7 @@ func (p *GotifyProvider) Schema(_ context.Context, _ provider.SchemaRequest, res
 			// All optional, ENV variables are also supported!
 			"endpoint": schema.StringAttribute{
 				Optional:    true,
-				Description: "Endpoint with Protocol to send requests to.",
+				Description: "Endpoint with Protocol to send requests to. May come from another resource, like a Gotify server deployed in the same configuration: With deferred actions enabled, Terraform plans the resources of this provider once it is known.",
 			},
 			"username": schema.StringAttribute{
 				Optional:    true,
@@ -54,11 +98,89 @@ func (p *GotifyProvider) Schema(_ context.Context, _ provider.SchemaRequest, res
 				Sensitive:   true,
 				Description: "The Password to authenticate against the server. Gotify's default \"admin\" user has \"admin\" as their password.",
 			},
+			"client_token": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				Description:         "A client token to authenticate against the server, instead of username and password.",
+				MarkdownDescription: "A client token to authenticate against the server, instead of `username` and `password`. It is sent as the `X-Gotify-Key` header. The token belongs to the user that created the client, managing users requires that user to be an admin.",
+			},
 			"host_header": schema.StringAttribute{
 				Optional:            true,
 				Description:         "Allows overwriting the Host header in all HTTP requests made to the Gotify REST API.",
 				MarkdownDescription: "This is useful when Gotify is deployed behind a reverse proxy and this provider is used in your infrastructure setup where DNS might not be available yet. You can then set the endpoint to an IP address and the Host to what your reverse Proxy expects.",
 			},
+			"ca_certificate": schema.StringAttribute{
+				Optional:            true,
+				Description:         "PEM encoded CA certificate(s) to trust when connecting to the server, or a path to a file containing them.",
+				MarkdownDescription: "PEM encoded CA certificate(s) to trust when connecting to the server, or a path to a file containing them. Trusted in addition to the system certificates. Can also be set via `GOTIFY_CA_CERTIFICATE`.",
+			},
+			"client_certificate": schema.StringAttribute{
+				Optional:            true,
+				Description:         "PEM encoded client certificate for mutual TLS, or a path to a file containing it.",
+				MarkdownDescription: "PEM encoded client certificate for mutual TLS, or a path to a file containing it. Requires `client_key`. Can also be set via `GOTIFY_CLIENT_CERTIFICATE`.",
+			},
+			"client_key": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				Description:         "PEM encoded private key of the client certificate, or a path to a file containing it.",
+				MarkdownDescription: "PEM encoded private key of the client certificate, or a path to a file containing it. Requires `client_certificate`. Can also be set via `GOTIFY_CLIENT_KEY`.",
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Optional:            true,
+				Description:         "Skips verification of the server certificate. Only use this for testing.",
+				MarkdownDescription: "Skips verification of the server certificate. Only use this for testing. Can also be set via `GOTIFY_INSECURE_SKIP_VERIFY`.",
+			},
+			"tls_server_name": schema.StringAttribute{
+				Optional:            true,
+				Description:         "Overrides the server name used to verify the server certificate.",
+				MarkdownDescription: "Overrides the server name used to verify the server certificate, which defaults to the host of the `endpoint`. Combine this with `host_header` when the endpoint is an IP address. Can also be set via `GOTIFY_TLS_SERVER_NAME`.",
+			},
+			"max_retries": schema.Int64Attribute{
+				Optional:            true,
+				Description:         "How often a request is retried when the server can not be reached or fails temporarily. Defaults to 3, 0 disables retries, at most 100.",
+				MarkdownDescription: "How often a request is retried when the server can not be reached, responds with `429` or a `5xx` status. Defaults to `3`, `0` disables retries. Requests that change the server and are not idempotent (like creating an application) are only retried if they certainly never reached Gotify. At most `100`.",
+				Validators: []validator.Int64{
+					int64validator.Between(0, maxRetriesLimit),
+				},
+			},
+			"retry_max_wait": schema.Int64Attribute{
+				Optional:            true,
+				Description:         "The maximum number of seconds to wait between two retries. Defaults to 30.",
+				MarkdownDescription: "The maximum number of seconds to wait between two retries. The wait time grows exponentially with every retry, up to this limit. Defaults to `30`.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"request_timeout": schema.Int64Attribute{
+				Optional:            true,
+				Description:         "The maximum number of seconds a single request to the server may take, including its retries. Defaults to 30.",
+				MarkdownDescription: "The maximum number of seconds a single request to the server may take, including its retries. Defaults to `30`. Waiting for the server with `wait_for_ready` is not limited by this. To limit how long a whole resource operation may take, use the `timeouts` block of the resource.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"wait_for_ready": schema.SingleNestedBlock{
+				Description:         "Waits for the server to report healthy before sending the first request to it.",
+				MarkdownDescription: "Waits for the server to report healthy on its `/health` endpoint before sending the first request to it. This allows deploying Gotify and configuring it in the same `terraform apply`. The wait only starts with the first request, so planning does not block on a server that is not deployed yet.",
+				Attributes: map[string]schema.Attribute{
+					"timeout": schema.Int64Attribute{
+						Optional:    true,
+						Description: "The maximum number of seconds to wait for the server. Defaults to 300.",
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+					"poll_interval": schema.Int64Attribute{
+						Optional:    true,
+						Description: "The number of seconds between two health checks. Defaults to 5.",
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -73,21 +195,30 @@ func (p *GotifyProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		return
 	}
 
-	// Default to ENV variables but override with explicit config
-	endpoint := os.Getenv("GOTIFY_ENDPOINT")
-	username := os.Getenv("GOTIFY_USERNAME")
-	password := os.Getenv("GOTIFY_PASSWORD")
-
-	if !model.Endpoint.IsNull() {
-		endpoint = model.Endpoint.ValueString()
-	}
-	if !model.Username.IsNull() {
-		username = model.Username.ValueString()
-	}
-	if !model.Password.IsNull() {
-		password = model.Password.ValueString()
+	// Values from other resources are unknown until those are applied, like the endpoint of a Gotify server that is
+	// deployed in the same configuration. The env variable fallback would silently replace them with empty strings.
+	if unknown := unknownAttributes(req.Config); len(unknown) > 0 {
+		if req.ClientCapabilities.DeferralAllowed {
+			tflog.Debug(ctx, "Deferring until the provider configuration is known", map[string]interface{}{"unknown": unknown})
+			resp.Deferred = &provider.Deferred{Reason: provider.DeferredReasonProviderConfigUnknown}
+			return
+		}
+		for _, name := range unknown {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(name),
+				"Unknown provider configuration",
+				fmt.Sprintf("The value of `%s` depends on resources that are not applied yet, so the provider can't connect to Gotify during this plan. This Terraform version does not support deferring the affected resources to a later plan.\n\nApply the resources the provider configuration depends on first, for example with `terraform apply -target=...`, or use a Terraform version with deferred actions enabled.", name),
+			)
+		}
+		return
 	}
 
+	// Default to ENV variables but override with explicit config
+	endpoint := configOrEnv(model.Endpoint, "GOTIFY_ENDPOINT")
+	username := configOrEnv(model.Username, "GOTIFY_USERNAME")
+	password := configOrEnv(model.Password, "GOTIFY_PASSWORD")
+	clientToken := configOrEnv(model.ClientToken, "GOTIFY_CLIENT_TOKEN")
+
 	// Verify we have values for everything
 	if endpoint == "" {
 		resp.Diagnostics.AddAttributeError(
@@ -96,25 +227,72 @@ func (p *GotifyProvider) Configure(ctx context.Context, req provider.ConfigureRe
 			"Configure the endpoint to reach the Gotify API, either via the `GOTIFY_ENDPOINT` environment variable, or configuration.",
 		)
 	}
-	if username == "" {
+	// Exactly one way to authenticate must be configured
+	useBasicAuth := username != "" || password != ""
+	useClientToken := clientToken != ""
+	if useBasicAuth && useClientToken {
 		resp.Diagnostics.AddAttributeError(
-			path.Root("username"),
-			"Missing Username configuration",
-			"Configure the username to authenticate against the Gotify API, either via `GOTIFY_USERNAME` environment variable, or configuration.",
+			path.Root("client_token"),
+			"Conflicting credentials configuration",
+			"Both username/password and a client token are configured, either via configuration or the `GOTIFY_USERNAME`, `GOTIFY_PASSWORD` and `GOTIFY_CLIENT_TOKEN` environment variables. Configure only one of them.",
 		)
-	}
-	if password == "" {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("password"),
-			"Missing Password configuration",
-			"Configure the password to authenticate against the Gotify API, either via `GOTIFY_PASSWORD` environment variable, or configuration.",
+	} else if !useBasicAuth && !useClientToken {
+		resp.Diagnostics.AddError(
+			"Missing credentials configuration",
+			"Configure either the username and password (or the `GOTIFY_USERNAME` and `GOTIFY_PASSWORD` environment variables), or a client token (or the `GOTIFY_CLIENT_TOKEN` environment variable) to authenticate against the Gotify API.",
 		)
+	} else if useBasicAuth {
+		if username == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("username"),
+				"Missing Username configuration",
+				"Configure the username to authenticate against the Gotify API, either via `GOTIFY_USERNAME` environment variable, or configuration.",
+			)
+		}
+		if password == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("password"),
+				"Missing Password configuration",
+				"Configure the password to authenticate against the Gotify API, either via `GOTIFY_PASSWORD` environment variable, or configuration.",
+			)
+		}
 	}
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	client, err := internal.NewAuthedClient(endpoint, username, password, model.HostHeader.ValueStringPointer())
+	tlsConfig := p.tlsConfig(&model, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	credentials := internal.Credentials{Username: username, Password: password, ClientToken: clientToken}
+	options := internal.ClientOptions{
+		HostHeader:     model.HostHeader.ValueStringPointer(),
+		TLS:            tlsConfig,
+		MaxRetries:     defaultMaxRetries,
+		RetryMaxWait:   defaultRetryMaxWait,
+		RequestTimeout: defaultRequestTimeout,
+	}
+	if !model.MaxRetries.IsNull() {
+		options.MaxRetries = int(model.MaxRetries.ValueInt64())
+	}
+	if !model.RetryMaxWait.IsNull() {
+		options.RetryMaxWait = time.Duration(model.RetryMaxWait.ValueInt64()) * time.Second
+	}
+	if !model.RequestTimeout.IsNull() {
+		options.RequestTimeout = time.Duration(model.RequestTimeout.ValueInt64()) * time.Second
+	}
+	if model.WaitForReady != nil {
+		options.WaitForReady = &internal.WaitForReady{Timeout: defaultReadyTimeout, PollInterval: defaultReadyPoll}
+		if !model.WaitForReady.Timeout.IsNull() {
+			options.WaitForReady.Timeout = time.Duration(model.WaitForReady.Timeout.ValueInt64()) * time.Second
+		}
+		if !model.WaitForReady.PollInterval.IsNull() {
+			options.WaitForReady.PollInterval = time.Duration(model.WaitForReady.PollInterval.ValueInt64()) * time.Second
+		}
+	}
+	client, err := internal.NewAuthedClient(endpoint, credentials, options)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed while constructing client", err.Error(),
@@ -125,6 +303,74 @@ func (p *GotifyProvider) Configure(ctx context.Context, req provider.ConfigureRe
 	// Make client available to data/resource
 	resp.DataSourceData = client
 	resp.ResourceData = client
+	resp.EphemeralResourceData = client
+}
+
+// Collects the TLS settings from configuration and ENV variables, loading PEM files where paths are given.
+func (p *GotifyProvider) tlsConfig(model *GotifyProviderModel, diags *diag.Diagnostics) internal.TLSConfig {
+	var config internal.TLSConfig
+	var err error
+
+	config.CACertificate, err = internal.LoadPEM(configOrEnv(model.CACertificate, "GOTIFY_CA_CERTIFICATE"))
+	if err != nil {
+		diags.AddAttributeError(path.Root("ca_certificate"), "Could not load CA certificate", err.Error())
+	}
+	config.ClientCertificate, err = internal.LoadPEM(configOrEnv(model.ClientCertificate, "GOTIFY_CLIENT_CERTIFICATE"))
+	if err != nil {
+		diags.AddAttributeError(path.Root("client_certificate"), "Could not load client certificate", err.Error())
+	}
+	config.ClientKey, err = internal.LoadPEM(configOrEnv(model.ClientKey, "GOTIFY_CLIENT_KEY"))
+	if err != nil {
+		diags.AddAttributeError(path.Root("client_key"), "Could not load client key", err.Error())
+	}
+	if (config.ClientCertificate == nil) != (config.ClientKey == nil) {
+		diags.AddAttributeError(
+			path.Root("client_certificate"),
+			"Incomplete client certificate configuration",
+			"Mutual TLS requires both the client certificate and its key. Configure both `client_certificate` and `client_key`, either via configuration or the `GOTIFY_CLIENT_CERTIFICATE` and `GOTIFY_CLIENT_KEY` environment variables.",
+		)
+	}
+
+	config.InsecureSkipVerify = model.InsecureSkipVerify.ValueBool()
+	if model.InsecureSkipVerify.IsNull() {
+		if env := os.Getenv("GOTIFY_INSECURE_SKIP_VERIFY"); env != "" {
+			config.InsecureSkipVerify, err = strconv.ParseBool(env)
+			if err != nil {
+				diags.AddAttributeError(
+					path.Root("insecure_skip_verify"),
+					"Invalid GOTIFY_INSECURE_SKIP_VERIFY environment variable",
+					fmt.Sprintf("Expected a boolean like \"true\" or \"false\", got %q.", env),
+				)
+			}
+		}
+	}
+	config.ServerName = configOrEnv(model.TLSServerName, "GOTIFY_TLS_SERVER_NAME")
+
+	return config
+}
+
+// Names of the attributes and blocks of the provider configuration whose values are not known yet.
+func unknownAttributes(config tfsdk.Config) []string {
+	var attributes map[string]tftypes.Value
+	if err := config.Raw.As(&attributes); err != nil {
+		return nil
+	}
+	var unknown []string
+	for name, value := range attributes {
+		if !value.IsFullyKnown() {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// Explicit configuration wins over the ENV variable.
+func configOrEnv(value types.String, env string) string {
+	if !value.IsNull() {
+		return value.ValueString()
+	}
+	return os.Getenv(env)
 }
 
 // All Resources this provider offers.
@@ -132,18 +378,41 @@ func (p *GotifyProvider) Resources(ctx context.Context) []func() resource.Resour
 	return []func() resource.Resource{
 		NewApplicationResource,
 		NewClientResource,
+		NewCurrentUserPasswordResource,
+		NewMessageResource,
 		NewPluginResource,
+		NewUserResource,
 	}
 }
 
 // All DataSources (read) this provider offers.
 func (p *GotifyProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewApplicationDataSource,
+		NewApplicationsDataSource,
+		NewClientDataSource,
+		NewClientsDataSource,
+		NewCurrentUserDataSource,
+		NewPluginsDataSource,
+		NewServerDataSource,
+	}
+}
+
+// All EphemeralResources (never stored in state) this provider offers.
+func (p *GotifyProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewClientEphemeralResource,
+		NewTokenEphemeralResource,
+	}
 }
 
 // All custom functions this provider offers.
 func (p *GotifyProvider) Functions(ctx context.Context) []func() function.Function {
-	return []func() function.Function{}
+	return []func() function.Function{
+		NewMessageUrlFunction,
+		NewStreamUrlFunction,
+		NewWebhookUrlFunction,
+	}
 }
 
 // Create new instance of this provider.
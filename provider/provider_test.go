@@ -1,10 +1,21 @@
 package provider
 
 import (
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"regexp"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 const (
@@ -26,5 +37,321 @@ var (
 )
 
 func testAccPreCheck(t *testing.T) {
-	// TODO check init via ENV variables!
+	if os.Getenv("GOTIFY_ENDPOINT") == "" {
+		t.Fatal("GOTIFY_ENDPOINT must be set to the Gotify server to run the tests against")
+	}
+}
+
+// Runs the test case against the real Gotify server from GOTIFY_ENDPOINT when TF_ACC is set (see docker-compose.test.yml).
+// Otherwise, it runs against a fresh fake server, so that `go test ./...` works without docker.
+func testAcc(t *testing.T, testCase resource.TestCase) {
+	if os.Getenv(resource.EnvTfAcc) != "" {
+		resource.Test(t, testCase)
+		return
+	}
+	testFake(t, newFakeGotify(t), testCase)
+}
+
+// Runs the test case against the given fake server, for tests that inject faults or drift.
+func testFake(t *testing.T, fake *fakeGotify, testCase resource.TestCase) {
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") == "" {
+		if _, err := exec.LookPath("terraform"); err != nil {
+			t.Skip("Terraform CLI not found, install it or set TF_ACC_TERRAFORM_PATH to run the tests")
+		}
+	}
+	t.Setenv("GOTIFY_ENDPOINT", fake.URL)
+	resource.UnitTest(t, testCase)
+}
+
+func TestProviderCredentials(t *testing.T) {
+	testAcc(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Both credential methods at once
+			{
+				Config: `
+provider "gotify" {
+ username = "admin"
+ password = "admin"
+ client_token = "t0k3n"
+}
+
+data "gotify_clients" "test" {}
+`,
+				ExpectError: regexp.MustCompile("Conflicting credentials configuration"),
+			},
+			// No credentials at all
+			{
+				Config: `
+provider "gotify" {}
+
+data "gotify_clients" "test" {}
+`,
+				ExpectError: regexp.MustCompile("Missing credentials configuration"),
+			},
+		},
+	})
+}
+
+func TestProviderRetries(t *testing.T) {
+	fake := newFakeGotify(t)
+	testFake(t, fake, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Transient errors are retried
+			{
+				PreConfig: func() {
+					fake.failNext("GET", "/client", http.StatusBadGateway, 2)
+				},
+				Config: `
+provider "gotify" {
+ username = "admin"
+ password = "admin"
+ max_retries = 2
+ retry_max_wait = 1
+}
+
+data "gotify_clients" "test" {}
+`,
+				Check: func(_ *terraform.State) error {
+					if count := fake.requestCount("GET", "/client"); count < 3 {
+						return fmt.Errorf("expected the failed requests to be retried, got %d requests", count)
+					}
+					return nil
+				},
+			},
+			// But not forever
+			{
+				PreConfig: func() {
+					fake.failNext("GET", "/client", http.StatusInternalServerError, 10)
+				},
+				Config: `
+provider "gotify" {
+ username = "admin"
+ password = "admin"
+ max_retries = 1
+ retry_max_wait = 1
+}
+
+data "gotify_clients" "test" {}
+`,
+				ExpectError: regexp.MustCompile(`(?s)Gotify server error.*max_retries`),
+			},
+		},
+	})
+}
+
+func TestProviderWaitForReady(t *testing.T) {
+	fake := newFakeGotify(t)
+	testFake(t, fake, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Server becomes healthy in time
+			{
+				PreConfig: func() {
+					fake.failNext("GET", "/health", http.StatusServiceUnavailable, 2)
+				},
+				Config: `
+provider "gotify" {
+ username = "admin"
+ password = "admin"
+ max_retries = 0
+
+ wait_for_ready {
+  timeout = 10
+  poll_interval = 1
+ }
+}
+
+data "gotify_clients" "test" {}
+`,
+				Check: func(_ *terraform.State) error {
+					if count := fake.requestCount("GET", "/health"); count < 3 {
+						return fmt.Errorf("expected to poll until healthy, got %d health checks", count)
+					}
+					return nil
+				},
+			},
+			// Server is too slow, the last response is reported
+			{
+				PreConfig: func() {
+					fake.failNext("GET", "/health", http.StatusServiceUnavailable, 100)
+				},
+				Config: `
+provider "gotify" {
+ username = "admin"
+ password = "admin"
+ max_retries = 0
+
+ wait_for_ready {
+  timeout = 2
+  poll_interval = 1
+ }
+}
+
+data "gotify_clients" "test" {}
+`,
+				ExpectError: regexp.MustCompile(`(?s)did not report healthy.*503 Service Unavailable`),
+			},
+			// Server doesn't answer within the timeout at all
+			{
+				PreConfig: func() {
+					fake.setLatency(1500 * time.Millisecond)
+				},
+				Config: `
+provider "gotify" {
+ username = "admin"
+ password = "admin"
+ max_retries = 0
+
+ wait_for_ready {
+  timeout = 1
+  poll_interval = 1
+ }
+}
+
+data "gotify_clients" "test" {}
+`,
+				ExpectError: regexp.MustCompile(`did not report healthy within 1s`),
+			},
+		},
+	})
+}
+
+func TestProviderRequestTimeout(t *testing.T) {
+	fake := newFakeGotify(t)
+	fake.setLatency(1500 * time.Millisecond)
+	testFake(t, fake, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// A hanging server fails the request instead of blocking forever
+			{
+				Config: `
+provider "gotify" {
+ username = "admin"
+ password = "admin"
+ max_retries = 0
+ request_timeout = 1
+}
+
+data "gotify_clients" "test" {}
+`,
+				ExpectError: regexp.MustCompile("context deadline exceeded"),
+			},
+			// The timeouts block of a resource limits the whole operation
+			{
+				Config: providerConfig + `
+resource "gotify_client" "test" {
+ name = "Slow"
+
+ timeouts {
+  create = "1s"
+ }
+}
+`,
+				ExpectError: regexp.MustCompile("context deadline exceeded"),
+			},
+		},
+	})
+}
+
+func TestProviderErrors(t *testing.T) {
+	fake := newFakeGotify(t)
+	fake.mutate(func(f *fakeGotify) {
+		f.addUser("bob", "bob", false)
+	})
+	testFake(t, fake, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Wrong password
+			{
+				Config: `
+provider "gotify" {
+ username = "admin"
+ password = "wrong"
+}
+
+data "gotify_clients" "test" {}
+`,
+				ExpectError: regexp.MustCompile(`(?s)Gotify rejected the credentials.*401 Unauthorized`),
+			},
+			// Only admins can manage users
+			{
+				Config: `
+provider "gotify" {
+ username = "bob"
+ password = "bob"
+}
+
+resource "gotify_user" "test" {
+ name = "alice"
+ pass = "alice"
+}
+`,
+				ExpectError: regexp.MustCompile(`(?s)Not allowed by Gotify.*name = "alice".*403 Forbidden`),
+			},
+		},
+	})
+}
+
+func TestProviderUnknownConfiguration(t *testing.T) {
+	fake := newFakeGotify(t)
+	testFake(t, fake, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Without deferred actions, the plan can't go on
+			{
+				Config: fmt.Sprintf(`
+resource "terraform_data" "endpoint" {
+ input = %q
+}
+
+provider "gotify" {
+ endpoint = terraform_data.endpoint.output
+ username = "admin"
+ password = "admin"
+}
+
+resource "gotify_client" "test" {
+ name = "Deferred"
+}
+`, fake.URL),
+				ExpectError: regexp.MustCompile(`(?s)Unknown provider configuration.*endpoint`),
+			},
+		},
+	})
+}
+
+func TestProviderDeferred(t *testing.T) {
+	ctx := t.Context()
+	p := NewProvider("test")()
+	var schemaResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+
+	// Like the endpoint of a Gotify server deployed in the same configuration
+	configType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := make(map[string]tftypes.Value, len(configType.AttributeTypes))
+	for name, attributeType := range configType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+	values["endpoint"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+	values["username"] = tftypes.NewValue(tftypes.String, "admin")
+	values["password"] = tftypes.NewValue(tftypes.String, "admin")
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(configType, values)}
+
+	var resp provider.ConfigureResponse
+	p.Configure(ctx, provider.ConfigureRequest{
+		Config:             config,
+		ClientCapabilities: provider.ConfigureProviderClientCapabilities{DeferralAllowed: true},
+	}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no errors when deferral is allowed, got %v", resp.Diagnostics)
+	}
+	if resp.Deferred == nil || resp.Deferred.Reason != provider.DeferredReasonProviderConfigUnknown {
+		t.Errorf("Expected the provider to defer because of its unknown configuration, got %+v", resp.Deferred)
+	}
+	if resp.ResourceData != nil {
+		t.Errorf("Expected no client to be configured, got %v", resp.ResourceData)
+	}
 }
@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"terraform-provider-gotify/provider/internal"
+
+	"github.com/gotify/go-api-client/v2/client/version"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	// Ensure implementation satisfies expected interfaces - compilition fails here otherwise.
+	_ datasource.DataSource              = &ServerDataSource{}
+	_ datasource.DataSourceWithConfigure = &ServerDataSource{}
+)
+
+type ServerDataSource struct {
+	gotify *internal.AuthedGotifyClient
+}
+
+func NewServerDataSource() datasource.DataSource {
+	return &ServerDataSource{}
+}
+
+type ServerDataSourceModel struct {
+	// Read-only
+	Version      types.String `tfsdk:"version"`
+	VersionMajor types.Int64  `tfsdk:"version_major"`
+	VersionMinor types.Int64  `tfsdk:"version_minor"`
+	VersionPatch types.Int64  `tfsdk:"version_patch"`
+	Commit       types.String `tfsdk:"commit"`
+	BuildDate    types.String `tfsdk:"build_date"`
+	Health       types.String `tfsdk:"health"`
+	Database     types.String `tfsdk:"database"`
+}
+
+func (d *ServerDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_server"
+}
+
+func (d *ServerDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "Version and health of the Gotify server.",
+		MarkdownDescription: "Version and health of the Gotify server. Use it in preconditions, for example to require a minimum server version before using features that need it.",
+		Attributes: map[string]schema.Attribute{
+			"version": schema.StringAttribute{
+				Computed:    true,
+				Description: "Version of the server, like 2.5.0.",
+			},
+			"version_major": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Major part of the version. Null if the version has an unexpected format, for example for development builds.",
+			},
+			"version_minor": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Minor part of the version. Null if the version has an unexpected format, for example for development builds.",
+			},
+			"version_patch": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Patch part of the version. Null if the version has an unexpected format, for example for development builds.",
+			},
+			"commit": schema.StringAttribute{
+				Computed:    true,
+				Description: "Git commit the server was built from.",
+			},
+			"build_date": schema.StringAttribute{
+				Computed:    true,
+				Description: "When the server was built.",
+			},
+			"health": schema.StringAttribute{
+				Computed:            true,
+				Description:         "Health of the server itself, green if healthy.",
+				MarkdownDescription: "Health of the server itself, `green` if healthy.",
+			},
+			"database": schema.StringAttribute{
+				Computed:            true,
+				Description:         "Health of the database connection, green if healthy.",
+				MarkdownDescription: "Health of the database connection, `green` if healthy.",
+			},
+		},
+	}
+}
+
+func (d *ServerDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		// IMPORTANT: This method is called MULTIPLE times. An initial call might not have configured the Provider yet, so we need
+		// to handle this gracefully. It will eventually be called with a configured provider.
+		return
+	}
+
+	client, ok := req.ProviderData.(*internal.AuthedGotifyClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *AuthedGotifyClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.gotify = client
+}
+
+func (d *ServerDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ServerDataSourceModel
+
+	params := version.NewGetVersionParamsWithContext(ctx)
+	info, err := d.gotify.Client.Version.GetVersion(params)
+	if err != nil {
+		resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Empty(), "Gotify API Request failed", err))
+		return
+	}
+
+	status, err := d.gotify.GetHealth(ctx)
+	if err != nil {
+		resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Empty(), "Gotify API Request failed", err))
+		return
+	}
+
+	data.Version = types.StringValue(info.Payload.Version)
+	data.VersionMajor, data.VersionMinor, data.VersionPatch = versionParts(info.Payload.Version)
+	data.Commit = types.StringValue(info.Payload.Commit)
+	data.BuildDate = types.StringValue(info.Payload.BuildDate)
+	data.Health = types.StringValue(status.Health)
+	data.Database = types.StringValue(status.Database)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Release versions, optionally with a "v" prefix or a suffix like "-rc1".
+var versionPattern = regexp.MustCompile(`^v?(\d+)\.(\d+)\.(\d+)`)
+
+// Splits the version into its major, minor and patch parts, which are null if the version has an unexpected format.
+func versionParts(version string) (types.Int64, types.Int64, types.Int64) {
+	match := versionPattern.FindStringSubmatch(version)
+	if match == nil {
+		return types.Int64Null(), types.Int64Null(), types.Int64Null()
+	}
+	parts := make([]types.Int64, 3)
+	for i, part := range match[1:] {
+		value, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			return types.Int64Null(), types.Int64Null(), types.Int64Null()
+		}
+		parts[i] = types.Int64Value(value)
+	}
+	return parts[0], parts[1], parts[2]
+}
@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestServerDataSource(t *testing.T) {
+	testAcc(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "gotify_server" "test" {
+ lifecycle {
+  postcondition {
+   condition     = self.version_major >= 2
+   error_message = "Expected Gotify 2 or newer"
+  }
+ }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.gotify_server.test", "version"),
+					resource.TestCheckResourceAttrSet("data.gotify_server.test", "version_minor"),
+					resource.TestCheckResourceAttrSet("data.gotify_server.test", "version_patch"),
+					resource.TestCheckResourceAttrSet("data.gotify_server.test", "commit"),
+					resource.TestCheckResourceAttrSet("data.gotify_server.test", "build_date"),
+					resource.TestCheckResourceAttr("data.gotify_server.test", "health", "green"),
+					resource.TestCheckResourceAttr("data.gotify_server.test", "database", "green"),
+				),
+			},
+		},
+	})
+}
+
+func TestServerDataSourceUnhealthy(t *testing.T) {
+	fake := newFakeGotify(t)
+	fake.mutate(func(f *fakeGotify) {
+		f.databaseDown = true
+	})
+	testFake(t, fake, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Gotify responds with status 500, which still reports the health
+			{
+				Config: providerConfig + `
+data "gotify_server" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.gotify_server.test", "health", "orange"),
+					resource.TestCheckResourceAttr("data.gotify_server.test", "database", "red"),
+				),
+			},
+		},
+	})
+}
+
+func TestVersionParts(t *testing.T) {
+	tests := []struct {
+		version  string
+		expected []types.Int64
+	}{
+		{"2.5.0", []types.Int64{types.Int64Value(2), types.Int64Value(5), types.Int64Value(0)}},
+		{"v2.10.3", []types.Int64{types.Int64Value(2), types.Int64Value(10), types.Int64Value(3)}},
+		{"2.6.0-rc1", []types.Int64{types.Int64Value(2), types.Int64Value(6), types.Int64Value(0)}},
+		{"unknown", []types.Int64{types.Int64Null(), types.Int64Null(), types.Int64Null()}},
+		{"", []types.Int64{types.Int64Null(), types.Int64Null(), types.Int64Null()}},
+	}
+	for _, test := range tests {
+		major, minor, patch := versionParts(test.version)
+		for i, part := range []types.Int64{major, minor, patch} {
+			if !part.Equal(test.expected[i]) {
+				t.Errorf("Expected %v as part %d of %q, got %v", test.expected[i], i, test.version, part)
+			}
+		}
+	}
+}
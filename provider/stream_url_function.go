@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure implementation satisfies expected interfaces - compilition fails here otherwise.
+var _ function.Function = &StreamUrlFunction{}
+
+type StreamUrlFunction struct{}
+
+func NewStreamUrlFunction() function.Function {
+	return &StreamUrlFunction{}
+}
+
+func (f *StreamUrlFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "stream_url"
+}
+
+func (f *StreamUrlFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "WebSocket URL a client receives new messages from",
+		MarkdownDescription: "Builds the WebSocket URL a client streams new messages from, with the client token as a query parameter. An `http` endpoint becomes `ws`, an `https` endpoint becomes `wss`. Any base path of the `endpoint` is kept.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "endpoint",
+				MarkdownDescription: "The Gotify endpoint with protocol, like the `endpoint` of the provider.",
+			},
+			function.StringParameter{
+				Name:                "client_token",
+				MarkdownDescription: "Token of the client, for example the `token` of a `gotify_client`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *StreamUrlFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var endpoint, token string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &endpoint, &token))
+	if resp.Error != nil {
+		return
+	}
+
+	u, err := endpointURL(endpoint, "/stream", true)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, withToken(u, token)))
+}
@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestStreamUrlFunction(t *testing.T) {
+	testAcc(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "secure" {
+ value = provider::gotify::stream_url("https://example.com/gotify/", "C.t0k3n")
+}
+
+output "plain" {
+ value = provider::gotify::stream_url("http://localhost:8080", "C.t0k3n")
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("secure", "wss://example.com/gotify/stream?token=C.t0k3n"),
+					resource.TestCheckOutput("plain", "ws://localhost:8080/stream?token=C.t0k3n"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,20 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// How long creating, reading, updating or deleting a resource may take, unless configured in its timeouts block.
+// Generous, because the first request might wait for the server to become ready.
+const defaultResourceTimeout = 20 * time.Minute
+
+// Limits the operation to the timeout from the timeouts block of the resource.
+// Callers must check the diagnostics, the timeout could not be parsed if they have errors.
+func withTimeout(ctx context.Context, timeout func(context.Context, time.Duration) (time.Duration, diag.Diagnostics), diags *diag.Diagnostics) (context.Context, context.CancelFunc) {
+	duration, d := timeout(ctx, defaultResourceTimeout)
+	diags.Append(d...)
+	return context.WithTimeout(ctx, duration)
+}
@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-gotify/provider/internal"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/ephemeralvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	// Ensure implementation satisfies expected interfaces - compilition fails here otherwise.
+	_ ephemeral.EphemeralResource                     = &TokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure        = &TokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigValidators = &TokenEphemeralResource{}
+)
+
+type TokenEphemeralResource struct {
+	gotify *internal.AuthedGotifyClient
+}
+
+func NewTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &TokenEphemeralResource{}
+}
+
+type TokenEphemeralResourceModel struct {
+	ApplicationId types.Int64  `tfsdk:"application_id"`
+	ClientId      types.Int64  `tfsdk:"client_id"`
+	ModulePath    types.String `tfsdk:"module_path"`
+	// Read-only after open
+	Token       types.String `tfsdk:"token"`
+	WebhookPath types.String `tfsdk:"webhook_path"`
+}
+
+func (r *TokenEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_token"
+}
+
+func (r *TokenEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "Reads the token of an existing application, client or plugin without writing it to the state.",
+		MarkdownDescription: "Reads the token of an existing application, client or plugin without writing it to the state or plan.\n\nUse it together with `store_token = false` on the `gotify_application`, `gotify_client` and `gotify_plugin` resources, so the tokens are only ever available in ephemeral contexts. Set exactly one of `application_id`, `client_id` or `module_path`.",
+		Attributes: map[string]schema.Attribute{
+			"application_id": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Numeric identifier of the application to read the token of.",
+			},
+			"client_id": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Numeric identifier of the client to read the token of.",
+			},
+			"module_path": schema.StringAttribute{
+				Optional:    true,
+				Description: "Module path of the plugin to read the token of.",
+			},
+			"token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The token of the application, client or plugin.",
+			},
+			"webhook_path": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				Description:         "Webhook base path of the plugin, relative to the endpoint. Null for applications and clients.",
+				MarkdownDescription: "Webhook base path of the plugin, relative to the endpoint. Null for applications and clients. See `webhook_path` of the `gotify_plugin` resource.",
+			},
+		},
+	}
+}
+
+func (r *TokenEphemeralResource) ConfigValidators(ctx context.Context) []ephemeral.ConfigValidator {
+	return []ephemeral.ConfigValidator{
+		ephemeralvalidator.ExactlyOneOf(path.MatchRoot("application_id"), path.MatchRoot("client_id"), path.MatchRoot("module_path")),
+	}
+}
+
+func (r *TokenEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		// IMPORTANT: This method is called MULTIPLE times. An initial call might not have configured the Provider yet, so we need
+		// to handle this gracefully. It will eventually be called with a configured provider.
+		return
+	}
+
+	client, ok := req.ProviderData.(*internal.AuthedGotifyClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *AuthedGotifyClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.gotify = client
+}
+
+func (r *TokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data TokenEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.WebhookPath = types.StringNull()
+	switch {
+	case !data.ApplicationId.IsNull():
+		app_list, err := r.gotify.GetApps(ctx)
+		if err != nil {
+			resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Root("application_id"), "Gotify API Request failed", err))
+			return
+		}
+		found := findApplications(app_list, data.ApplicationId, types.StringNull())
+		if len(found) == 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("application_id"), "Could not find application", fmt.Sprintf("There is no application with id %d.", data.ApplicationId.ValueInt64()))
+			return
+		}
+		data.Token = types.StringValue(found[0].Token)
+	case !data.ClientId.IsNull():
+		client_list, err := r.gotify.GetClients(ctx)
+		if err != nil {
+			resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Root("client_id"), "Gotify API Request failed", err))
+			return
+		}
+		found := findClients(client_list, data.ClientId, types.StringNull())
+		if len(found) == 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("client_id"), "Could not find client", fmt.Sprintf("There is no client with id %d.", data.ClientId.ValueInt64()))
+			return
+		}
+		data.Token = types.StringValue(found[0].Token)
+	default:
+		plugin_list, err := r.gotify.GetPlugins(ctx)
+		if err != nil {
+			resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Root("module_path"), "Gotify API Request failed", err))
+			return
+		}
+		found := findPlugin(plugin_list, data.ModulePath.ValueString())
+		if found == nil {
+			resp.Diagnostics.AddAttributeError(path.Root("module_path"), "Plugin is not installed", pluginNotInstalled(plugin_list, data.ModulePath.ValueString()))
+			return
+		}
+		data.Token = types.StringValue(found.Token)
+		data.WebhookPath = toWebhookPath(found.ID, data.Token)
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
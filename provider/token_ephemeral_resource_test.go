@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestTokenEphemeralResource(t *testing.T) {
+	testAcc(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccEchoProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		Steps: []resource.TestStep{
+			// Test Open() for all kinds of tokens
+			{
+				Config: providerConfig + `
+resource "gotify_application" "test" {
+ name = "Without token"
+ store_token = false
+}
+
+resource "gotify_client" "test" {
+ name = "Without token"
+ store_token = false
+}
+
+resource "gotify_plugin" "test" {
+ module_path = "github.com/LukasKnuth/gotify-slack-webhook"
+ enabled = false
+ store_token = false
+}
+
+ephemeral "gotify_token" "application" {
+ application_id = gotify_application.test.id
+}
+
+ephemeral "gotify_token" "client" {
+ client_id = gotify_client.test.id
+}
+
+ephemeral "gotify_token" "plugin" {
+ module_path = gotify_plugin.test.module_path
+}
+
+provider "echo" {
+ data = {
+  application  = ephemeral.gotify_token.application.token
+  client       = ephemeral.gotify_token.client.token
+  plugin       = ephemeral.gotify_token.plugin.token
+  webhook_path = ephemeral.gotify_token.plugin.webhook_path
+ }
+}
+
+resource "echo" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("gotify_application.test", "token"),
+					resource.TestCheckNoResourceAttr("gotify_client.test", "token"),
+					resource.TestCheckNoResourceAttr("gotify_plugin.test", "token"),
+					resource.TestCheckNoResourceAttr("gotify_plugin.test", "webhook_path"),
+					resource.TestCheckResourceAttrSet("echo.test", "data.application"),
+					resource.TestCheckResourceAttrSet("echo.test", "data.client"),
+					resource.TestCheckResourceAttrSet("echo.test", "data.plugin"),
+					resource.TestCheckResourceAttrSet("echo.test", "data.webhook_path"),
+				),
+			},
+		},
+	})
+}
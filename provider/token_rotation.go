@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// The `rotation_triggers` attribute shared by all resources with a token.
+//
+// Gotify can not rotate a token in place, so rotating means replacing the whole object. Adding or removing the map
+// does not rotate, so that existing resources can adopt it without losing their token.
+func rotationTriggersAttribute(kind string) schema.MapAttribute {
+	return schema.MapAttribute{
+		Optional:            true,
+		ElementType:         types.StringType,
+		Description:         fmt.Sprintf("Arbitrary values that rotate the token when changed. Gotify can not rotate tokens in place, so the %s is replaced.", kind),
+		MarkdownDescription: fmt.Sprintf("Arbitrary values that rotate the `token` when changed, similar to `keepers` of the `random` provider. Gotify can not rotate tokens in place, so the %s is replaced: it gets a new `id` and a new `token`. Adding or removing the map does not rotate the token, only changing its values does.", kind),
+		PlanModifiers: []planmodifier.Map{
+			mapplanmodifier.RequiresReplaceIf(
+				func(ctx context.Context, req planmodifier.MapRequest, resp *mapplanmodifier.RequiresReplaceIfFuncResponse) {
+					resp.RequiresReplace = !req.StateValue.IsNull() && !req.PlanValue.IsNull()
+				},
+				"Changing the rotation triggers replaces the resource to rotate its token.",
+				"Changing the rotation triggers replaces the resource to rotate its token.",
+			),
+		},
+	}
+}
+
+// Makes it obvious in the plan that a token rotation is not a simple update.
+// Must be called from ModifyPlan, after the attribute plan modifiers ran.
+func warnTokenRotation(resp *resource.ModifyPlanResponse, kind string) {
+	if resp.RequiresReplace.Contains(path.Root("rotation_triggers")) {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("rotation_triggers"),
+			"Token rotation replaces the "+kind,
+			fmt.Sprintf("Gotify can not rotate tokens in place. The %s is deleted and created again with a new id and token, everything that used the old token must be updated.", kind),
+		)
+	}
+}
@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// The `store_token` attribute shared by all resources with a token.
+func storeTokenAttribute(kind string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Optional:            true,
+		Computed:            true,
+		Default:             booldefault.StaticBool(true),
+		Description:         fmt.Sprintf("Whether the token of the %s is kept in the Terraform state. Defaults to true.", kind),
+		MarkdownDescription: fmt.Sprintf("Whether the `token` of the %s is kept in the Terraform state. Defaults to `true`. If `false`, the `token` is always null and the ephemeral `gotify_token` resource reads it when needed. Changes made outside of Terraform are still detected through the other attributes. Data sources that read the same %s keep its token in state unless their `store_token` is `false` as well.", kind, kind),
+	}
+}
+
+// Whether the token is kept in state. Imported resources and state from older provider versions have no value, they
+// always stored the token.
+func storeToken(store types.Bool) bool {
+	return store.IsNull() || store.ValueBool()
+}
+
+// The token as it is written to state.
+func storedToken(store types.Bool, token string) types.String {
+	if !storeToken(store) {
+		return types.StringNull()
+	}
+	return types.StringValue(token)
+}
@@ -0,0 +1,271 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"terraform-provider-gotify/provider/internal"
+
+	"github.com/gotify/go-api-client/v2/client/user"
+	"github.com/gotify/go-api-client/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	// Ensure implementation satisfies expected interfaces - compilition fails here otherwise.
+	_ resource.Resource              = &UserResource{}
+	_ resource.ResourceWithConfigure = &UserResource{}
+)
+
+type UserResource struct {
+	gotify *internal.AuthedGotifyClient
+}
+
+func NewUserResource() resource.Resource {
+	return &UserResource{}
+}
+
+type UserResourceModel struct {
+	Name          types.String   `tfsdk:"name"`
+	Pass          types.String   `tfsdk:"pass"`
+	PassWo        types.String   `tfsdk:"pass_wo"`
+	PassWoVersion types.Int64    `tfsdk:"pass_wo_version"`
+	Admin         types.Bool     `tfsdk:"admin"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+	// Read-only after apply
+	Id types.Int64 `tfsdk:"id"`
+}
+
+func (r *UserResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user"
+}
+
+func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "A user account on the Gotify server. Each user has their own applications, clients and messages.",
+		MarkdownDescription: "The provider must be configured with an admin user to manage other users.\n\nThe password is only ever sent to the server, it can not be read back. Changing it outside of Terraform will therefore not be detected. With Terraform 1.11 or newer, prefer the write-only `pass_wo`, which is never stored in the plan or state.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+				Description: "Numeric identifier of this specific User.",
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the user. This is also the username used to log in.",
+			},
+			"pass": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				Description:         "The password the user logs in with. Stored in the Terraform state, exactly one of pass or pass_wo must be set.",
+				MarkdownDescription: "The password the user logs in with. Stored in the Terraform state, exactly one of `pass` or `pass_wo` must be set.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("pass_wo")),
+				},
+			},
+			"pass_wo": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				Description:         "The password the user logs in with. Write-only, it is never stored in the Terraform state. Requires Terraform 1.11 or newer.",
+				MarkdownDescription: "The password the user logs in with. Write-only, it is never stored in the Terraform plan or state. Requires Terraform 1.11 or newer. Changes to it are not detected, change `pass_wo_version` to send a new password.",
+			},
+			"pass_wo_version": schema.Int64Attribute{
+				Optional:            true,
+				Description:         "Change this to send a new pass_wo to the server.",
+				MarkdownDescription: "Change this to send a new `pass_wo` to the server. Terraform can not detect changes to write-only values on its own.",
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("pass_wo")),
+				},
+			},
+			"admin": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether the user has administrative rights, allowing them to manage other users.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Update: true, Delete: true}),
+		},
+	}
+}
+
+func (r *UserResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		// IMPORTANT: This method is called MULTIPLE times. An initial call might not have configured the Provider yet, so we need
+		// to handle this gracefully. It will eventually be called with a configured provider.
+		return
+	}
+
+	client, ok := req.ProviderData.(*internal.AuthedGotifyClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AuthedGotifyClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.gotify = client
+}
+
+func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data UserResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	// Write-only values are only in the config, never in the plan
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("pass_wo"), &data.PassWo)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Creating user", map[string]interface{}{"name": data.Name.ValueString()})
+
+	params := user.NewCreateUserParamsWithContext(ctx)
+	params.Body = toUserWithPass(&data)
+	new_user, err := r.gotify.Client.User.CreateUser(params, r.gotify.Auth)
+	if err != nil {
+		resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Root("name"), "Gotify API Request failed", err))
+		return
+	}
+
+	// The password is never returned, keep whatever was planned
+	data.Id = types.Int64Value(int64(new_user.Payload.ID))
+	data.Name = types.StringValue(new_user.Payload.Name)
+	data.Admin = types.BoolValue(new_user.Payload.Admin)
+	data.PassWo = types.StringNull()
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state UserResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Reading user", map[string]interface{}{"id": state.Id.ValueInt64()})
+
+	params := user.NewGetUserParamsWithContext(ctx)
+	params.ID = state.Id.ValueInt64()
+	found, err := r.gotify.Client.User.GetUser(params, r.gotify.Auth)
+	var notFound *user.GetUserNotFound
+	if err != nil && !errors.As(err, &notFound) {
+		resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Root("id"), "Gotify API Request failed", err))
+		return
+	}
+
+	if err == nil {
+		// Update information on state
+		state.Name = types.StringValue(found.Payload.Name)
+		state.Admin = types.BoolValue(found.Payload.Admin)
+
+		// Write new information to tf-state
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	} else {
+		// The User is no longer there, remove it and let terraform re-create it later.
+		// https://discuss.hashicorp.com/t/how-should-read-signal-that-a-resource-has-vanished-from-the-api-server/40833/2
+		resp.State.RemoveResource(ctx)
+	}
+}
+
+func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data UserResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	// Write-only values are only in the config, never in the plan
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("pass_wo"), &data.PassWo)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Update, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Updating user", map[string]interface{}{"id": data.Id.ValueInt64(), "name": data.Name.ValueString()})
+
+	params := user.NewUpdateUserParamsWithContext(ctx)
+	params.ID = data.Id.ValueInt64()
+	params.Body = toUserWithPass(&data)
+	updated_user, err := r.gotify.Client.User.UpdateUser(params, r.gotify.Auth)
+	if err != nil {
+		resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Root("id"), "Gotify API Request failed", err))
+		return
+	}
+
+	data.Name = types.StringValue(updated_user.Payload.Name)
+	data.Admin = types.BoolValue(updated_user.Payload.Admin)
+	data.PassWo = types.StringNull()
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state UserResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Delete, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Deleting user", map[string]interface{}{"id": state.Id.ValueInt64()})
+
+	params := user.NewDeleteUserParamsWithContext(ctx)
+	params.ID = state.Id.ValueInt64()
+	_, err := r.gotify.Client.User.DeleteUser(params, r.gotify.Auth)
+	if err != nil {
+		resp.Diagnostics.Append(internal.ErrorDiagnostic(path.Root("id"), "Gotify API Request failed", err))
+		return
+	}
+}
+
+func toUserWithPass(data *UserResourceModel) *models.UserExternalWithPass {
+	pass := data.Pass
+	if !data.PassWo.IsNull() {
+		pass = data.PassWo
+	}
+	return &models.UserExternalWithPass{
+		UserExternal: models.UserExternal{
+			Name:  data.Name.ValueString(),
+			Admin: data.Admin.ValueBool(),
+		},
+		UserExternalPass: models.UserExternalPass{
+			Pass: pass.ValueString(),
+		},
+	}
+}
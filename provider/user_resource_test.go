@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestUserResource(t *testing.T) {
+	testAcc(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Test Create() and Read()
+			{
+				Config: providerConfig + `
+resource "gotify_user" "test" {
+ name = "testing"
+ pass = "secret"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("gotify_user.test", "name", "testing"),
+					resource.TestCheckResourceAttr("gotify_user.test", "admin", "false"),
+					resource.TestCheckResourceAttrSet("gotify_user.test", "id"),
+				),
+			},
+			// Test Update() and Read()
+			{
+				Config: providerConfig + `
+resource "gotify_user" "test" {
+ name = "changed"
+ pass = "other-secret"
+ admin = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("gotify_user.test", "name", "changed"),
+					resource.TestCheckResourceAttr("gotify_user.test", "admin", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestUserResourceWriteOnlyPass(t *testing.T) {
+	fake := newFakeGotify(t)
+	checkPass := func(expected string) resource.TestCheckFunc {
+		return func(_ *terraform.State) error {
+			var pass string
+			fake.mutate(func(f *fakeGotify) {
+				pass = f.userByName("write-only").Pass
+			})
+			if pass != expected {
+				return fmt.Errorf("expected password %q on the server, got %q", expected, pass)
+			}
+			return nil
+		}
+	}
+	testFake(t, fake, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "gotify_user" "test" {
+ name = "write-only"
+ pass_wo = "secret"
+ pass_wo_version = 1
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("gotify_user.test", "pass"),
+					resource.TestCheckNoResourceAttr("gotify_user.test", "pass_wo"),
+					checkPass("secret"),
+				),
+			},
+			// Bumping the version sends the new password
+			{
+				Config: providerConfig + `
+resource "gotify_user" "test" {
+ name = "write-only"
+ pass_wo = "other-secret"
+ pass_wo_version = 2
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("gotify_user.test", "pass_wo"),
+					checkPass("other-secret"),
+				),
+			},
+		},
+	})
+}
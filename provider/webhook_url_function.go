@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure implementation satisfies expected interfaces - compilition fails here otherwise.
+var _ function.Function = &WebhookUrlFunction{}
+
+type WebhookUrlFunction struct{}
+
+func NewWebhookUrlFunction() function.Function {
+	return &WebhookUrlFunction{}
+}
+
+func (f *WebhookUrlFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "webhook_url"
+}
+
+func (f *WebhookUrlFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "URL of a plugins custom webhook handler",
+		MarkdownDescription: "Builds the full URL of a custom webhook handler registered by a plugin, the same way `webhook_path` of `gotify_plugin` does. Any base path of the `endpoint` is kept.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "endpoint",
+				MarkdownDescription: "The Gotify endpoint with protocol, like the `endpoint` of the provider.",
+			},
+			function.Int64Parameter{
+				Name:                "plugin_id",
+				MarkdownDescription: "Numeric identifier of the plugin.",
+			},
+			function.StringParameter{
+				Name:                "token",
+				MarkdownDescription: "Token of the plugin, for example the `token` of a `gotify_plugin`.",
+			},
+			function.StringParameter{
+				Name:                "subpath",
+				MarkdownDescription: "The path the plugin registered its handler under, see the plugin description. Can be empty.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *WebhookUrlFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var endpoint, token, subpath string
+	var id int64
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &endpoint, &id, &token, &subpath))
+	if resp.Error != nil {
+		return
+	}
+
+	path := webhookPath(id, token)
+	if subpath = strings.TrimLeft(subpath, "/"); subpath != "" {
+		path += "/" + subpath
+	}
+	u, err := endpointURL(endpoint, path, false)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, u.String()))
+}
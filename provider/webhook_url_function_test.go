@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestWebhookUrlFunction(t *testing.T) {
+	testAcc(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "subpath" {
+ value = provider::gotify::webhook_url("https://example.com/gotify/", 1, "t0k3n", "/slack_webhook")
+}
+
+output "root" {
+ value = provider::gotify::webhook_url("http://localhost:8080", 2, "t0k3n", "")
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("subpath", "https://example.com/gotify/plugin/1/custom/t0k3n/slack_webhook"),
+					resource.TestCheckOutput("root", "http://localhost:8080/plugin/2/custom/t0k3n"),
+				),
+			},
+		},
+	})
+}